@@ -2,4 +2,8 @@ module github.com/richardanchieta/llm-scan-tool
 
 go 1.23.0
 
-require github.com/sabhiram/go-gitignore v0.0.0-20210923224102-525f6e181f06
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/sabhiram/go-gitignore v0.0.0-20210923224102-525f6e181f06
+	gopkg.in/yaml.v3 v3.0.1
+)
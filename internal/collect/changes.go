@@ -0,0 +1,251 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// Changes resume o que o branch mudou em relação à base (diff desde o merge-base).
+type Changes struct {
+	Base        string        `json:"base"`
+	MergeBase   string        `json:"merge_base"`
+	Head        string        `json:"head"` // commit de -rev ou "working tree"
+	Files       []ChangedFile `json:"files"`
+	Analyzed    int           `json:"analyzed_files"` // alterados + vizinhos de pacote
+	GoRequires  []ListChange  `json:"go_requires,omitempty"`
+	ProtoRPCs   []ListChange  `json:"proto_rpcs,omitempty"`
+	Migrations  *ListChange   `json:"migrations,omitempty"`
+	MakeTargets []ListChange  `json:"make_targets,omitempty"`
+}
+
+// ChangedFile é um arquivo do diff: Status A (adicionado), M (modificado) ou D (removido).
+type ChangedFile struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+}
+
+// ListChange é o que entrou, saiu ou mudou em uma lista extraída de um arquivo.
+type ListChange struct {
+	File    string   `json:"file,omitempty"`
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+}
+
+func (lc ListChange) empty() bool {
+	return len(lc.Added) == 0 && len(lc.Removed) == 0 && len(lc.Changed) == 0
+}
+
+// collectChanges calcula os arquivos alterados desde o merge-base de base com o
+// commit do snapshot (-rev) ou, sem snapshot, com o working tree.
+func collectChanges(ctx context.Context, root, base string, snap *files.GitFS) (*Changes, error) {
+	head, headName := "HEAD", "working tree"
+	if snap != nil {
+		head, headName = snap.Commit, snap.Commit
+	}
+	out, err := git(ctx, root, "merge-base", base, head)
+	if err != nil {
+		return nil, fmt.Errorf("merge-base %s: %w", base, err)
+	}
+	ch := &Changes{Base: base, MergeBase: strings.TrimSpace(string(out)), Head: headName}
+	args := []string{"diff", "--name-status", "--no-renames", "-z", "--relative", ch.MergeBase}
+	if snap != nil {
+		args = append(args, snap.Commit)
+	}
+	out, err = git(ctx, root, append(args, "--")...)
+	if err != nil {
+		return nil, err
+	}
+	// "-z": status\0caminho\0status\0caminho\0...
+	fields := strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		st := fields[i]
+		if st == "" {
+			continue
+		}
+		if st[0] != 'A' && st[0] != 'D' {
+			st = "M" // M, T (tipo), U (conflito)
+		}
+		ch.Files = append(ch.Files, ChangedFile{Path: fields[i+1], Status: st[:1]})
+	}
+	sort.Slice(ch.Files, func(i, j int) bool { return ch.Files[i].Path < ch.Files[j].Path })
+	return ch, nil
+}
+
+// focus devolve os arquivos que os coletores devem analisar: os alterados, os
+// outros arquivos dos mesmos diretórios (vizinhos de pacote) e todo go.mod/go.work,
+// que resolvem imports e binários.
+func (ch *Changes) focus(paths []string) map[string]bool {
+	dirs := map[string]bool{}
+	for _, f := range ch.Files {
+		dirs[path.Dir(f.Path)] = true
+	}
+	set := map[string]bool{}
+	for _, p := range paths {
+		base := path.Base(p)
+		if dirs[path.Dir(p)] || base == "go.mod" || base == "go.work" {
+			set[p] = true
+		}
+	}
+	ch.Analyzed = len(set)
+	return set
+}
+
+// diffContents compara, nos arquivos alterados, requires do go.mod, RPCs dos
+// .proto e alvos de Makefile entre o merge-base e a versão atual, e lista as
+// migrations SQL adicionadas, removidas ou editadas.
+func (ch *Changes) diffContents(ctx context.Context, source *files.Source, root string) {
+	prefix := ""
+	if out, err := git(ctx, root, "rev-parse", "--show-prefix"); err == nil {
+		prefix = strings.TrimSpace(string(out))
+	}
+	old := func(p string) string {
+		out, err := git(ctx, root, "show", ch.MergeBase+":"+prefix+p)
+		if err != nil {
+			return ""
+		}
+		return string(out)
+	}
+	cur := func(p string, status string) string {
+		if status == "D" {
+			return ""
+		}
+		data, err := source.ReadFile(filepath.Join(root, filepath.FromSlash(p)))
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+	mig := ListChange{}
+	for _, f := range ch.Files {
+		lower := strings.ToLower(f.Path)
+		base := path.Base(lower)
+		var lc ListChange
+		switch {
+		case base == "go.mod":
+			lc = diffVersions(goModRequireVersions(old(f.Path)), goModRequireVersions(cur(f.Path, f.Status)))
+			if !lc.empty() {
+				lc.File = f.Path
+				ch.GoRequires = append(ch.GoRequires, lc)
+			}
+		case strings.HasSuffix(lower, ".proto"):
+			lc = diffLists(protoRPCNames(old(f.Path)), protoRPCNames(cur(f.Path, f.Status)))
+			if !lc.empty() {
+				lc.File = f.Path
+				ch.ProtoRPCs = append(ch.ProtoRPCs, lc)
+			}
+		case base == "makefile" || strings.HasSuffix(lower, ".mk"):
+			lc = diffLists(makeTargetsFromSource(old(f.Path)), makeTargetsFromSource(cur(f.Path, f.Status)))
+			if !lc.empty() {
+				lc.File = f.Path
+				ch.MakeTargets = append(ch.MakeTargets, lc)
+			}
+		case isSQLMigration(lower):
+			switch f.Status {
+			case "A":
+				mig.Added = append(mig.Added, f.Path)
+			case "D":
+				mig.Removed = append(mig.Removed, f.Path)
+			default:
+				mig.Changed = append(mig.Changed, f.Path) // migration já aplicada sendo editada
+			}
+		}
+	}
+	if !mig.empty() {
+		ch.Migrations = &mig
+	}
+}
+
+// protoRPCNames lista as RPCs como "Service.Rpc".
+func protoRPCNames(src string) []string {
+	if src == "" {
+		return nil
+	}
+	pi := parseProtoSource("", src)
+	var out []string
+	for _, svc := range pi.Services {
+		for _, rpc := range pi.serviceRPCs[svc] {
+			out = append(out, svc+"."+rpc)
+		}
+	}
+	return out
+}
+
+// goModRequireVersions lê os requires de um go.mod como módulo -> versão.
+func goModRequireVersions(src string) map[string]string {
+	out := map[string]string{}
+	inBlock := false
+	for _, ln := range strings.Split(src, "\n") {
+		if i := strings.Index(ln, "//"); i >= 0 {
+			ln = ln[:i]
+		}
+		ln = strings.TrimSpace(ln)
+		switch {
+		case inBlock && ln == ")":
+			inBlock = false
+			continue
+		case ln == "require (" || ln == "require(":
+			inBlock = true
+			continue
+		case strings.HasPrefix(ln, "require "):
+			ln = strings.TrimSpace(strings.TrimPrefix(ln, "require"))
+		case !inBlock:
+			continue
+		}
+		if f := strings.Fields(ln); len(f) >= 2 {
+			out[f[0]] = f[1]
+		}
+	}
+	return out
+}
+
+// diffVersions compara dois mapas módulo -> versão.
+func diffVersions(before, after map[string]string) ListChange {
+	var lc ListChange
+	for m, v := range after {
+		switch old, ok := before[m]; {
+		case !ok:
+			lc.Added = append(lc.Added, m+" "+v)
+		case old != v:
+			lc.Changed = append(lc.Changed, m+" "+old+" → "+v)
+		}
+	}
+	for m, v := range before {
+		if _, ok := after[m]; !ok {
+			lc.Removed = append(lc.Removed, m+" "+v)
+		}
+	}
+	sort.Strings(lc.Added)
+	sort.Strings(lc.Removed)
+	sort.Strings(lc.Changed)
+	return lc
+}
+
+// diffLists devolve o que entrou e o que saiu entre duas listas.
+func diffLists(before, after []string) ListChange {
+	inBefore, inAfter := map[string]bool{}, map[string]bool{}
+	for _, s := range before {
+		inBefore[s] = true
+	}
+	for _, s := range after {
+		inAfter[s] = true
+	}
+	var lc ListChange
+	for _, s := range dedupeSorted(after) {
+		if !inBefore[s] {
+			lc.Added = append(lc.Added, s)
+		}
+	}
+	for _, s := range dedupeSorted(before) {
+		if !inAfter[s] {
+			lc.Removed = append(lc.Removed, s)
+		}
+	}
+	return lc
+}
@@ -0,0 +1,82 @@
+package collect
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGoModRequireVersions(t *testing.T) {
+	tests := []struct {
+		name, src string
+		want      map[string]string
+	}{
+		{
+			name: "bloco e linha única",
+			src: `module example.com/m
+
+go 1.22
+
+require github.com/a/x v1.0.0 // indirect
+
+require (
+	github.com/b/y v0.1.0
+	// comentário
+	github.com/c/z v2.3.0+incompatible // indirect
+)
+
+replace github.com/b/y => ../y
+`,
+			want: map[string]string{
+				"github.com/a/x": "v1.0.0",
+				"github.com/b/y": "v0.1.0",
+				"github.com/c/z": "v2.3.0+incompatible",
+			},
+		},
+		{name: "sem requires", src: "module example.com/m\n\ngo 1.22\n", want: map[string]string{}},
+		{name: "vazio (arquivo removido)", src: "", want: map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := goModRequireVersions(tt.src); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("goModRequireVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffVersions(t *testing.T) {
+	before := map[string]string{"a": "v1.0.0", "b": "v0.1.0", "c": "v1.2.0"}
+	after := map[string]string{"a": "v1.2.0", "c": "v1.2.0", "d": "v0.3.0"}
+	want := ListChange{
+		Added:   []string{"d v0.3.0"},
+		Removed: []string{"b v0.1.0"},
+		Changed: []string{"a v1.0.0 → v1.2.0"},
+	}
+	if got := diffVersions(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffVersions() = %+v, want %+v", got, want)
+	}
+	if got := diffVersions(before, before); !got.empty() {
+		t.Errorf("diffVersions(x, x) = %+v, want empty", got)
+	}
+}
+
+func TestDiffLists(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after []string
+		want          ListChange
+	}{
+		{"entra e sai", []string{"build", "lint"}, []string{"test", "build", "vet"}, ListChange{Added: []string{"test", "vet"}, Removed: []string{"lint"}}},
+		{"duplicados e vazios", []string{"S.A", "S.A"}, []string{"S.B", "S.B", ""}, ListChange{Added: []string{"S.B"}, Removed: []string{"S.A"}}},
+		{"arquivo novo", nil, []string{"b", "a"}, ListChange{Added: []string{"a", "b"}}},
+		{"arquivo removido", []string{"a"}, nil, ListChange{Removed: []string{"a"}}},
+		{"sem mudança", []string{"a", "b"}, []string{"b", "a"}, ListChange{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffLists(tt.before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffLists() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package collect
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// Classes de arquivos que não contam como código escrito à mão.
+const (
+	classGenerated = "generated"
+	classVendored  = "vendored"
+	classMinified  = "minified"
+	classLockfile  = "lockfile"
+)
+
+var (
+	lockfileNames = map[string]bool{
+		"go.sum": true, "go.work.sum": true, "package-lock.json": true, "npm-shrinkwrap.json": true,
+		"yarn.lock": true, "pnpm-lock.yaml": true, "bun.lockb": true, "bun.lock": true, "cargo.lock": true,
+		"poetry.lock": true, "pipfile.lock": true, "pdm.lock": true, "uv.lock": true, "composer.lock": true,
+		"gemfile.lock": true, "gradle.lockfile": true, "flake.lock": true, "mix.lock": true,
+		"pubspec.lock": true, "packages.lock.json": true, "podfile.lock": true, "package.resolved": true,
+	}
+	vendoredDirs = map[string]bool{
+		"vendor": true, "third_party": true, "third-party": true, "thirdparty": true,
+		"node_modules": true, "bower_components": true,
+	}
+	generatedNameRe = regexp.MustCompile(`(\.pb(\.gw|\.validate)?\.go|_grpc\.pb\.go|_mock\.go|_mocks?_test\.go|\.generated\.[a-z]+|_generated\.go|_pb2(_grpc)?\.pyi?|\.pb\.(h|cc|ts|js))$|^(mock_.*\.go|zz_generated.*)$`)
+	// só vale nos comentários do topo do arquivo; "do not edit" solto precisa
+	// fechar a frase ("DO NOT EDIT.", "do not edit this file", "do not edit manually")
+	generatedMarkRe = regexp.MustCompile(`(?im)(code generated|@generated|auto-?generated|automatically generated|do not (edit|modify)([ \t]+(this file|manually|by hand))?[ \t]*[.!]?[ \t]*(\*/|-->)?[ \t]*\r?$)`)
+	minifiedNameRe  = regexp.MustCompile(`[.-]min\.(js|mjs|css)$`)
+)
+
+// BinaryStats conta os arquivos binários deixados de fora da varredura.
+type BinaryStats struct {
+	Files int   `json:"files"`
+	Bytes int64 `json:"bytes"`
+}
+
+// classifyFile diz se o arquivo é gerado, vendorizado, minificado ou lockfile
+// ("" para código escrito à mão). Nome e caminho resolvem a maioria dos casos;
+// só então lê o início do arquivo (marcadores de geração, linhas gigantes).
+func classifyFile(source *files.Source, full, rel string) string {
+	lower := strings.ToLower(rel)
+	base := path.Base(lower)
+	switch {
+	case lockfileNames[base]:
+		return classLockfile
+	case isVendoredPath(lower):
+		return classVendored
+	case generatedNameRe.MatchString(base):
+		return classGenerated
+	case minifiedNameRe.MatchString(base):
+		return classMinified
+	}
+	ext := path.Ext(base)
+	minifiable := ext == ".js" || ext == ".mjs" || ext == ".cjs" || ext == ".css"
+	head, err := source.ReadHead(full, 4096)
+	if err != nil {
+		return ""
+	}
+	if ext == ".go" {
+		// convenção do Go: "// Code generated ... DO NOT EDIT." antes do package
+		if i := strings.Index("\n"+head, "\npackage "); i >= 0 {
+			head = head[:i]
+		}
+		if generatedRe.MatchString(head) {
+			return classGenerated
+		}
+		return ""
+	}
+	if generatedMarkRe.MatchString(leadingComments(head, detectLanguage(source, full, lower))) {
+		return classGenerated
+	}
+	if minifiable && longestLine(head) >= 1000 {
+		return classMinified
+	}
+	return ""
+}
+
+func isVendoredPath(lower string) bool {
+	segs := strings.Split(lower, "/")
+	for _, s := range segs[:len(segs)-1] {
+		if vendoredDirs[s] {
+			return true
+		}
+	}
+	return false
+}
+
+// genericSyntax cobre os comentários mais comuns quando a linguagem é desconhecida.
+var genericSyntax = &langSyntax{Line: []string{"#", "//", "--", ";"}, Block: [][2]string{{"/*", "*/"}, {"<!--", "-->"}}}
+
+// leadingComments devolve as linhas de comentário do topo de s, até a primeira
+// linha com código (o shebang é pulado).
+func leadingComments(s string, syn *langSyntax) string {
+	if syn == nil || (len(syn.Line) == 0 && len(syn.Block) == 0) {
+		syn = genericSyntax
+	}
+	var b strings.Builder
+	inBlock := -1
+	for i, ln := range strings.Split(s, "\n") {
+		if i == 0 && strings.HasPrefix(ln, "#!") {
+			continue
+		}
+		hasCode, hasComment := scanLine(ln, syn, &inBlock)
+		if hasCode {
+			break
+		}
+		if hasComment {
+			b.WriteString(ln)
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+func longestLine(s string) int {
+	max := 0
+	for _, ln := range strings.Split(s, "\n") {
+		if len(ln) > max {
+			max = len(ln)
+		}
+	}
+	return max
+}
@@ -0,0 +1,48 @@
+package collect
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClassifyFile(t *testing.T) {
+	tests := []struct {
+		name, rel, src, want string
+	}{
+		{"lockfile", "web/package-lock.json", "{}", classLockfile},
+		{"vendor", "vendor/github.com/x/y/y.go", "package y\n", classVendored},
+		{"node_modules", "web/node_modules/react/index.js", "module.exports = 1\n", classVendored},
+		{"nome .pb.go", "gen/api.pb.go", "package api\n", classGenerated},
+		{"nome .min.js", "static/app.min.js", "var a=1\n", classMinified},
+		{"go com marcador oficial", "x/enum_string.go", "// Code generated by \"stringer -type=Kind\"; DO NOT EDIT.\n\npackage x\n", classGenerated},
+		{"go com licença antes do marcador", "x/gen.go", "// Copyright 2024 Acme\n// SPDX-License-Identifier: MIT\n\n// Code generated by tool. DO NOT EDIT.\n\npackage x\n", classGenerated},
+		{"go escrito à mão com \"do not edit\"", "x/handwritten.go", "// Package x: do not edit the constants below without\n// updating the migration.\npackage x\n\nconst A = 1\n", ""},
+		{"go com marcador depois do package", "x/late.go", "package x\n\n// Code generated by tool. DO NOT EDIT.\nconst A = 1\n", ""},
+		{"go com marcador fora do padrão", "x/auto.go", "// This file is auto-generated.\npackage x\n", ""},
+		{"ts com @generated", "web/src/api.ts", "/* eslint-disable */\n/**\n * @generated by openapi-typescript\n */\nexport type A = string\n", classGenerated},
+		{"python com DO NOT EDIT", "py/gen/models.py", "#!/usr/bin/env python3\n# Automatically generated file.\n# DO NOT EDIT.\nimport x\n", classGenerated},
+		{"python com aviso no corpo", "py/app.py", "import os\n\n# do not edit this file\nX = 1\n", ""},
+		{"python com frase que continua", "py/consts.py", "# do not edit the constants below\nA = 1\n", ""},
+		{"sql gerado", "db/schema.sql", "-- Code generated by sqlc. DO NOT EDIT.\nCREATE TABLE a();\n", classGenerated},
+		{"html gerado", "docs/index.html", "<!-- DO NOT EDIT -->\n<html></html>\n", classGenerated},
+		{"js minificado pelo conteúdo", "static/bundle.js", "!function(){" + strings.Repeat("var a=1;", 200) + "}()\n", classMinified},
+		{"código comum", "cmd/main.go", "package main\n\nfunc main() {}\n", ""},
+	}
+	root := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			full := filepath.Join(root, filepath.FromSlash(tt.rel))
+			if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(full, []byte(tt.src), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if got := classifyFile(nil, full, tt.rel); got != tt.want {
+				t.Errorf("classifyFile(nil, %s) = %q, want %q", tt.rel, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package collect
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CodeGen reúne o pipeline de geração de código do repo Go.
+type CodeGen struct {
+	Generate  []GenerateDirective `json:"generate,omitempty"`
+	Generated []GeneratedFile     `json:"generated,omitempty"`
+	Embeds    []EmbedDirective    `json:"embeds,omitempty"`
+	BuildTags []BuildConstraint   `json:"build_tags,omitempty"`
+}
+
+// GenerateDirective é uma linha //go:generate.
+type GenerateDirective struct {
+	Where   string `json:"where"` // arquivo:linha
+	Tool    string `json:"tool"`
+	Command string `json:"command"`
+}
+
+// GeneratedFile é um arquivo com o cabeçalho "// Code generated ... DO NOT EDIT."
+// e, quando dá para deduzir, de onde ele vem.
+type GeneratedFile struct {
+	File       string `json:"file"`
+	Generator  string `json:"generator,omitempty"`  // texto após "Code generated by"
+	Directive  string `json:"directive,omitempty"`  // //go:generate correspondente (arquivo:linha)
+	Regenerate string `json:"regenerate,omitempty"` // comando para regenerar
+}
+
+// EmbedDirective é um //go:embed com os arquivos que os padrões alcançam.
+type EmbedDirective struct {
+	Where    string   `json:"where"`
+	Var      string   `json:"var,omitempty"`
+	Patterns []string `json:"patterns"`
+	Assets   []string `json:"assets,omitempty"`
+}
+
+// BuildConstraint agrupa os arquivos por expressão //go:build.
+type BuildConstraint struct {
+	Expr  string   `json:"expr"`
+	Files []string `json:"files"`
+}
+
+// goCodegenFacts é a contribuição de um arquivo .go.
+type goCodegenFacts struct {
+	Rel       string
+	Generate  []GenerateDirective
+	Embeds    []EmbedDirective
+	Build     string
+	Generated bool
+	Generator string
+}
+
+var generatedByRe = regexp.MustCompile(`(?m)^// Code generated (?:by )?(.*?)\.? DO NOT EDIT\.$`)
+
+// codegenFactsFromSource lê as diretivas //go:generate, //go:embed e //go:build
+// e o cabeçalho de código gerado.
+func codegenFactsFromSource(gs *goSource) goCodegenFacts {
+	facts := goCodegenFacts{Rel: gs.Rel, Generated: gs.IsGenerated()}
+	if facts.Generated {
+		if m := generatedByRe.FindSubmatch(gs.Src); m != nil {
+			// "by protoc-gen-go", "by \"stringer -type=Color\";", "- DO NOT EDIT"
+			facts.Generator = strings.Trim(string(m[1]), "-\"; ")
+		}
+	}
+	pkgPos := gs.File.Package
+	for _, cg := range gs.File.Comments {
+		for _, c := range cg.List {
+			text := c.Text
+			switch {
+			case strings.HasPrefix(text, "//go:generate "):
+				cmd := strings.TrimSpace(strings.TrimPrefix(text, "//go:generate"))
+				facts.Generate = append(facts.Generate, GenerateDirective{
+					Where:   fmt.Sprintf("%s:%d", gs.Rel, gs.Line(c.Pos())),
+					Tool:    generateTool(cmd),
+					Command: cmd,
+				})
+			case strings.HasPrefix(text, "//go:build ") && c.Pos() < pkgPos:
+				facts.Build = strings.TrimSpace(strings.TrimPrefix(text, "//go:build"))
+			case strings.HasPrefix(text, "//go:embed "):
+				e := EmbedDirective{
+					Where:    fmt.Sprintf("%s:%d", gs.Rel, gs.Line(c.Pos())),
+					Patterns: embedPatterns(strings.TrimPrefix(text, "//go:embed ")),
+				}
+				e.Var = embedVar(gs, cg)
+				facts.Embeds = append(facts.Embeds, e)
+			}
+		}
+	}
+	return facts
+}
+
+// generateTool deduz a ferramenta chamada por um //go:generate:
+// "go run pkg/cmd/tool@v1" -> tool, "go tool x" -> x, "mockgen ..." -> mockgen.
+func generateTool(cmd string) string {
+	words := strings.Fields(cmd)
+	if len(words) == 0 {
+		return ""
+	}
+	if words[0] == "go" && len(words) > 2 && (words[1] == "run" || words[1] == "tool") {
+		for _, w := range words[2:] {
+			if !strings.HasPrefix(w, "-") {
+				w, _, _ = strings.Cut(w, "@")
+				return importBaseName(w)
+			}
+		}
+	}
+	return path.Base(words[0])
+}
+
+// embedPatterns separa os padrões de um //go:embed (aceita "aspas" e `crases`).
+func embedPatterns(s string) []string {
+	var out []string
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimSpace(s) {
+		if s[0] == '"' || s[0] == '`' {
+			if end := strings.IndexByte(s[1:], s[0]); end >= 0 {
+				out = append(out, s[1:end+1])
+				s = s[end+2:]
+				continue
+			}
+		}
+		f, rest, _ := strings.Cut(s, " ")
+		out = append(out, f)
+		s = rest
+	}
+	return out
+}
+
+// embedVar devolve o nome da variável documentada pelo grupo de comentários.
+func embedVar(gs *goSource, cg *ast.CommentGroup) string {
+	for _, d := range gs.File.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			if vs, ok := spec.(*ast.ValueSpec); ok && (vs.Doc == cg || gd.Doc == cg) && len(vs.Names) > 0 {
+				return vs.Names[0].Name
+			}
+		}
+	}
+	return ""
+}
+
+// buildCodeGen resolve os assets de cada //go:embed, agrupa as build tags e
+// liga cada arquivo gerado à diretiva ou ferramenta que o produz.
+func buildCodeGen(facts []goCodegenFacts, paths []string) *CodeGen {
+	cg := &CodeGen{}
+	tags := map[string][]string{}
+	var generated []goCodegenFacts
+	for _, f := range facts {
+		cg.Generate = append(cg.Generate, f.Generate...)
+		for _, e := range f.Embeds {
+			e.Assets = embedAssets(path.Dir(f.Rel), e.Patterns, paths)
+			cg.Embeds = append(cg.Embeds, e)
+		}
+		if f.Build != "" {
+			tags[f.Build] = append(tags[f.Build], f.Rel)
+		}
+		if f.Generated {
+			generated = append(generated, f)
+		}
+	}
+	if len(cg.Generate) == 0 && len(cg.Embeds) == 0 && len(tags) == 0 && len(generated) == 0 {
+		return nil
+	}
+	sort.Slice(cg.Generate, func(i, j int) bool { return lessWhere(cg.Generate[i].Where, cg.Generate[j].Where) })
+	sort.Slice(cg.Embeds, func(i, j int) bool { return lessWhere(cg.Embeds[i].Where, cg.Embeds[j].Where) })
+	for expr, files := range tags {
+		sort.Strings(files)
+		cg.BuildTags = append(cg.BuildTags, BuildConstraint{Expr: expr, Files: files})
+	}
+	sort.Slice(cg.BuildTags, func(i, j int) bool { return cg.BuildTags[i].Expr < cg.BuildTags[j].Expr })
+
+	pathSet := map[string]bool{}
+	for _, p := range paths {
+		pathSet[p] = true
+	}
+	for _, f := range generated {
+		gf := GeneratedFile{File: f.Rel, Generator: f.Generator}
+		if d, ok := matchGenerateDirective(f, cg.Generate); ok {
+			gf.Directive = d.Where
+			dir := path.Dir(strings.SplitN(d.Where, ":", 2)[0])
+			gf.Regenerate = "go generate ./" + dir
+			if dir == "." {
+				gf.Regenerate = "go generate ."
+			}
+		} else {
+			gf.Regenerate = knownRegenerate(f, pathSet)
+		}
+		cg.Generated = append(cg.Generated, gf)
+	}
+	sort.Slice(cg.Generated, func(i, j int) bool { return cg.Generated[i].File < cg.Generated[j].File })
+	return cg
+}
+
+// lessWhere ordena "arquivo:linha" por arquivo e depois numericamente pela linha.
+func lessWhere(a, b string) bool {
+	fa, la, _ := strings.Cut(a, ":")
+	fb, lb, _ := strings.Cut(b, ":")
+	if fa != fb {
+		return fa < fb
+	}
+	na, _ := strconv.Atoi(la)
+	nb, _ := strconv.Atoi(lb)
+	return na < nb
+}
+
+// matchGenerateDirective procura o //go:generate que produz o arquivo: no mesmo
+// diretório, citando o nome do arquivo (-destination, -o, > arquivo) ou cuja
+// ferramenta bate com o "Code generated by".
+func matchGenerateDirective(f goCodegenFacts, dirs []GenerateDirective) (GenerateDirective, bool) {
+	dir, base := path.Dir(f.Rel), path.Base(f.Rel)
+	gen := strings.ToLower(f.Generator)
+	var byTool *GenerateDirective
+	for i, d := range dirs {
+		file := strings.SplitN(d.Where, ":", 2)[0]
+		if strings.Contains(d.Command, f.Rel) || (path.Dir(file) == dir && strings.Contains(d.Command, base)) {
+			return d, true
+		}
+		tool := strings.ToLower(d.Tool)
+		if byTool == nil && path.Dir(file) == dir && tool != "" && gen != "" &&
+			(strings.Contains(gen, tool) || strings.Contains(tool, strings.Fields(gen)[0])) {
+			byTool = &dirs[i]
+		}
+	}
+	if byTool != nil {
+		return *byTool, true
+	}
+	return GenerateDirective{}, false
+}
+
+// knownRegenerate cobre geradores que normalmente rodam fora do go:generate.
+func knownRegenerate(f goCodegenFacts, pathSet map[string]bool) string {
+	gen := strings.ToLower(f.Generator)
+	switch {
+	case strings.HasSuffix(f.Rel, ".pb.go") || strings.HasPrefix(gen, "protoc-gen"):
+		for _, cfg := range []string{"buf.gen.yaml", "buf.gen.yml"} {
+			if pathSet[cfg] {
+				return "buf generate"
+			}
+		}
+		return "protoc (" + f.Generator + ")"
+	case strings.Contains(gen, "sqlc"):
+		return "sqlc generate"
+	case strings.Contains(gen, "gqlgen"):
+		return "go run github.com/99designs/gqlgen generate"
+	case strings.Contains(gen, "wire"):
+		return "wire ./" + path.Dir(f.Rel)
+	}
+	return ""
+}
+
+// embedAssets expande os padrões de //go:embed (relativos ao diretório do
+// pacote). Um padrão que casa com um diretório inclui a árvore inteira,
+// exceto arquivos iniciados por "." ou "_" (a menos de "all:").
+func embedAssets(dir string, patterns, paths []string) []string {
+	prefix := dirPrefix(dir)
+	var out []string
+	for _, p := range paths {
+		if !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		rel := strings.TrimPrefix(p, prefix)
+		comps := strings.Split(rel, "/")
+	pattern:
+		for _, pat := range patterns {
+			all := strings.HasPrefix(pat, "all:")
+			pat = strings.TrimPrefix(pat, "all:")
+			for i := 1; i <= len(comps); i++ {
+				if ok, _ := path.Match(pat, strings.Join(comps[:i], "/")); !ok {
+					continue
+				}
+				if !all {
+					for _, c := range comps[i:] {
+						if strings.HasPrefix(c, ".") || strings.HasPrefix(c, "_") {
+							continue pattern
+						}
+					}
+				}
+				out = append(out, p)
+				break pattern
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,212 @@
+package collect
+
+import (
+	"bufio"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	ignore "github.com/sabhiram/go-gitignore"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// Ownership resume o CODEOWNERS aplicado à árvore, aos módulos Go e aos protos.
+type Ownership struct {
+	File    string              `json:"file"`
+	Rules   []OwnerRule         `json:"rules"`
+	Dirs    map[string][]string `json:"dirs,omitempty"` // diretórios da árvore podada -> owners
+	Teams   []OwnerTeam         `json:"teams,omitempty"`
+	Unowned []string            `json:"unowned_top_level,omitempty"`
+}
+
+// OwnerRule é uma linha do CODEOWNERS.
+type OwnerRule struct {
+	Line    int      `json:"line"`
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"` // vazio = sem dono (desfaz regras anteriores)
+}
+
+// OwnerTeam lista o que cada owner (usuário, @org/time ou e-mail) possui.
+type OwnerTeam struct {
+	Owner   string   `json:"owner"`
+	Dirs    []string `json:"dirs,omitempty"`
+	Modules []string `json:"go_modules,omitempty"`
+	Protos  []string `json:"proto_packages,omitempty"`
+}
+
+type ownerMatcher struct {
+	rule OwnerRule
+	gi   *ignore.GitIgnore
+}
+
+// codeOwners aplica as regras com a semântica do GitHub: a última que casa vence.
+type codeOwners struct {
+	rules []ownerMatcher
+}
+
+// codeownersLocations segue a ordem de busca do GitHub: o primeiro encontrado vale.
+var codeownersLocations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+func parseCodeOwners(source *files.Source, full string) (*codeOwners, []OwnerRule, error) {
+	f, err := source.Open(full)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() { _ = f.Close() }()
+	co := &codeOwners{}
+	var rules []OwnerRule
+	sc := bufio.NewScanner(f)
+	n := 0
+	for sc.Scan() {
+		n++
+		line := strings.TrimSpace(sc.Text())
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		r := OwnerRule{Line: n, Pattern: fields[0], Owners: fields[1:]}
+		rules = append(rules, r)
+		co.rules = append(co.rules, ownerMatcher{rule: r, gi: ignore.CompileIgnoreLines(r.Pattern)})
+	}
+	return co, rules, sc.Err()
+}
+
+// ownersOf devolve os owners de um caminho ("dir/" para diretórios).
+func (co *codeOwners) ownersOf(rel string) []string {
+	var owners []string
+	for _, m := range co.rules {
+		if !m.gi.MatchesPath(rel) {
+			continue
+		}
+		// No CODEOWNERS, "docs/*" não alcança subdiretórios (no .gitignore alcança).
+		if strings.HasSuffix(m.rule.Pattern, "/*") {
+			prefix := strings.TrimPrefix(strings.TrimSuffix(m.rule.Pattern, "*"), "/")
+			if i := strings.Index(rel, prefix); i >= 0 && strings.Contains(rel[i+len(prefix):], "/") {
+				continue
+			}
+		}
+		owners = m.rule.Owners
+	}
+	return owners
+}
+
+// buildOwnership resolve os owners dos diretórios da árvore (até depth níveis),
+// dos módulos Go e dos arquivos .proto (caminhos absolutos), e agrupa tudo por owner.
+func buildOwnership(source *files.Source, root string, paths []string, depth int, mods []GoModule, protos []ProtoInfo) *Ownership {
+	pathSet := map[string]bool{}
+	for _, p := range paths {
+		pathSet[p] = true
+	}
+	var file string
+	for _, loc := range codeownersLocations {
+		if pathSet[loc] {
+			file = loc
+			break
+		}
+	}
+	if file == "" {
+		return nil
+	}
+	co, rules, err := parseCodeOwners(source, filepath.Join(root, file))
+	if err != nil {
+		return nil
+	}
+	if depth <= 0 {
+		depth = 3
+	}
+	own := &Ownership{File: file, Rules: rules, Dirs: map[string][]string{}}
+
+	dirSet := map[string]bool{}
+	topLevel := map[string]bool{}
+	for _, p := range paths {
+		parts := strings.Split(p, "/")
+		if len(parts) > 1 && (!strings.HasPrefix(parts[0], ".") || parts[0] == ".github") {
+			topLevel[parts[0]] = true
+		}
+		for i := 1; i < len(parts) && i <= depth; i++ {
+			dirSet[strings.Join(parts[:i], "/")] = true
+		}
+	}
+	teams := map[string]*OwnerTeam{}
+	team := func(o string) *OwnerTeam {
+		if teams[o] == nil {
+			teams[o] = &OwnerTeam{Owner: o}
+		}
+		return teams[o]
+	}
+	dirs := make([]string, 0, len(dirSet))
+	for d := range dirSet {
+		dirs = append(dirs, d)
+	}
+	sort.Strings(dirs)
+	for _, d := range dirs {
+		owners := co.ownersOf(d + "/")
+		if len(owners) > 0 {
+			own.Dirs[d] = owners
+		}
+	}
+	// um diretório entra na lista do owner só onde a posse começa (difere do pai)
+	for _, d := range dirs {
+		owners := own.Dirs[d]
+		if parent := path.Dir(d); parent != "." && sameOwners(own.Dirs[parent], owners) {
+			continue
+		}
+		for _, o := range owners {
+			team(o).Dirs = append(team(o).Dirs, d)
+		}
+	}
+	for i, m := range mods {
+		rel, err := filepath.Rel(root, m.Path)
+		if err != nil {
+			continue
+		}
+		mods[i].Owners = co.ownersOf(filepath.ToSlash(rel))
+		for _, o := range mods[i].Owners {
+			team(o).Modules = append(team(o).Modules, strings.TrimSpace(m.Module))
+		}
+	}
+	for i, p := range protos {
+		rel, err := filepath.Rel(root, p.File)
+		if err != nil {
+			continue
+		}
+		pkg := p.Package
+		if pkg == "" {
+			pkg = filepath.ToSlash(rel)
+		}
+		protos[i].Owners = co.ownersOf(filepath.ToSlash(rel))
+		for _, o := range protos[i].Owners {
+			team(o).Protos = append(team(o).Protos, pkg)
+		}
+	}
+	for _, t := range teams {
+		t.Modules = dedupeSorted(t.Modules)
+		t.Protos = dedupeSorted(t.Protos)
+		own.Teams = append(own.Teams, *t)
+	}
+	sort.Slice(own.Teams, func(i, j int) bool { return own.Teams[i].Owner < own.Teams[j].Owner })
+	for d := range topLevel {
+		if len(own.Dirs[d]) == 0 {
+			own.Unowned = append(own.Unowned, d)
+		}
+	}
+	sort.Strings(own.Unowned)
+	return own
+}
+
+func sameOwners(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
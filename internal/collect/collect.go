@@ -25,6 +25,11 @@ type Config struct {
 	IncludeGlobsCSV string
 	ExcludeGlobsCSV string
 	TreeDepth       int
+	SkipGenerated   bool   // ignora arquivos gerados em todos os coletores e na árvore
+	Base            string // ref base do modo "changed files" (merge-base com HEAD ou -rev)
+	Rev             string // revisão git a varrer direto do banco de objetos ("" = working tree)
+	HistoryDays     int    // janela do "Recent Activity" (git log); 0 desliga
+	LargeAssetBytes int64  // arquivos fora do LFS a partir deste tamanho vão para "Large Assets" (0 desliga)
 }
 
 // ReadmeSummary guarda um extrato leve de um README (título/objetivo/primeiro parágrafo).
@@ -43,35 +48,83 @@ type CoverageSummary struct {
 	Percent      float64  `json:"percent"`        // (CoveredStmts / TotalStmts) * 100
 	HasGoProfile bool     `json:"has_go_profile"` // se achou coverage.out/coverprofile
 	BDD          BDDSum   `json:"bdd"`
+
+	Packages       []PackageCoverage `json:"packages,omitempty"`        // cobertura por pacote (import path)
+	Files          []FileCoverage    `json:"files,omitempty"`           // cobertura por arquivo
+	LeastCovered   []string          `json:"least_covered,omitempty"`   // pacotes com menor % (até 10)
+	UncoveredFuncs []UncoveredFunc   `json:"uncovered_funcs,omitempty"` // funções exportadas sem cobertura
 }
 
 // BDDSum agrega insumos de BDD (features + relatórios Cucumber).
 type BDDSum struct {
 	FeatureFiles int      `json:"feature_files"` // número de arquivos .feature
-	Reports      []string `json:"reports"`       // caminhos de cucumber.json ou junit.xml
+	Reports      []string `json:"reports"`       // caminhos de cucumber.json (JUnit XML vai para TestResults)
 	Features     int      `json:"features"`      // contados via cucumber.json (se existir)
 	Scenarios    int      `json:"scenarios"`
 	Steps        int      `json:"steps"`
+
+	Specs []GherkinFeature `json:"specs,omitempty"` // catálogo extraído dos próprios .feature
+
+	// status dos passos no cucumber.json (result.status)
+	Passed           int                     `json:"passed"`
+	Failed           int                     `json:"failed"`
+	Skipped          int                     `json:"skipped"`
+	Pending          int                     `json:"pending"`
+	Undefined        int                     `json:"undefined"`
+	FeatureResults   []CucumberFeatureResult `json:"feature_results,omitempty"`
+	FailingScenarios []FailingScenario       `json:"failing_scenarios,omitempty"`
+
+	// step definitions godog x passos Gherkin
+	StepDefinitions int             `json:"step_definitions"`
+	UndefinedSteps  []UndefinedStep `json:"undefined_steps,omitempty"`
+	UnusedStepDefs  []StepDef       `json:"unused_step_defs,omitempty"`
 }
 
 // Summary é o objeto principal agregado pelo coletor; base para render Markdown/JSON.
 type Summary struct {
-	Root            string                   `json:"root"`
-	GeneratedAt     time.Time                `json:"generated_at"`
-	GoModules       []GoModule               `json:"go_modules"`
-	Proto           []ProtoInfo              `json:"proto"`
-	MakeTargets     []string                 `json:"make_targets"`
-	Dockerfiles     []string                 `json:"dockerfiles"`
-	SQLMigrations   []string                 `json:"sql_migrations"`
-	Decisions       []Decision               `json:"decisions"`
-	EnvExamples     []string                 `json:"env_examples"`
-	Licenses        []string                 `json:"licenses"`
-	Readmes         []string                 `json:"readmes"`
-	ReadmeSummaries map[string]ReadmeSummary `json:"readme_summaries"`
-	TechStats       map[string]int           `json:"tech_stats"`
-	Tree            []string                 `json:"tree"`
-	NotableConfigs  []string                 `json:"notable_configs"`
-	TestCoverage    *CoverageSummary         `json:"test_coverage"`
+	Root            string                    `json:"root"`
+	GeneratedAt     time.Time                 `json:"generated_at"`
+	Rev             string                    `json:"rev,omitempty"`    // revisão pedida em -rev
+	Commit          string                    `json:"commit,omitempty"` // commit resolvido de -rev
+	GoModules       []GoModule                `json:"go_modules"`
+	Proto           []ProtoInfo               `json:"proto"`
+	APISpecs        []APISpec                 `json:"openapi"`
+	GraphQLSchemas  []GraphQLSchema           `json:"graphql_schemas"`
+	Gqlgen          []GqlgenConfig            `json:"gqlgen"`
+	AsyncAPIs       []AsyncAPISpec            `json:"asyncapi"`
+	EventFlows      []EventFlow               `json:"event_flows"`
+	HTTPEndpoints   []HTTPService             `json:"http_endpoints"`
+	DI              []DIGraph                 `json:"dependency_injection"`
+	CodeGen         *CodeGen                  `json:"codegen"`
+	MakeTargets     []string                  `json:"make_targets"`
+	Dockerfiles     []string                  `json:"dockerfiles"`
+	SQLMigrations   []string                  `json:"sql_migrations"`
+	Decisions       []Decision                `json:"decisions"`
+	EnvExamples     []string                  `json:"env_examples"`
+	Licenses        []string                  `json:"licenses"`
+	Readmes         []string                  `json:"readmes"`
+	ReadmeSummaries map[string]ReadmeSummary  `json:"readme_summaries"`
+	TechStats       map[string]int            `json:"tech_stats"`   // só código escrito à mão
+	FileClasses     map[string]map[string]int `json:"file_classes"` // generated/vendored/minified/lockfile -> ext -> arquivos
+	SkipGenerated   bool                      `json:"skip_generated,omitempty"`
+	Changes         *Changes                  `json:"changes"`
+	History         *History                  `json:"history"`
+	Ownership       *Ownership                `json:"ownership"`
+	LFS             *LFSSummary               `json:"lfs"`
+	LargeAssets     []LargeAsset              `json:"large_assets"`
+	LargeAssetBytes int64                     `json:"large_asset_threshold"`
+	Binaries        BinaryStats               `json:"binary_files"`
+	LanguageStats   *LanguageStats            `json:"language_stats"`
+	Tree            []string                  `json:"tree"`
+	NotableConfigs  []NotableConfig           `json:"notable_configs"`
+	TestCoverage    *CoverageSummary          `json:"test_coverage"`
+	TestResults     *TestResults              `json:"test_results"`
+	Tests           *TestInventory            `json:"tests"`
+	RustCrates      []RustCrate               `json:"rust_crates"`
+	RustWorkspaces  []RustWorkspace           `json:"rust_workspaces"`
+	PythonProjects  []PythonProject           `json:"python_projects"`
+	JSPackages      []JSPackage               `json:"js_packages"`
+	JVMProjects     []JVMProject              `json:"jvm_projects"`
 }
 
 // GoModule descreve um módulo Go encontrado (path/module/requires).
@@ -79,6 +132,10 @@ type GoModule struct {
 	Path     string   `json:"path"`
 	Module   string   `json:"module"`
 	Requires []string `json:"requires"`
+	Internal []string `json:"internal,omitempty"` // módulos do próprio repo dos quais depende
+	Owners   []string `json:"owners,omitempty"`   // CODEOWNERS do go.mod
+
+	localReplaces map[string]string // replace x => ../dir
 }
 
 // ProtoInfo descreve um arquivo/projeto Protobuf (package, services, RPCs).
@@ -87,6 +144,15 @@ type ProtoInfo struct {
 	Package  string   `json:"package"`
 	Services []string `json:"services"`
 	RPCs     []string `json:"rpcs"`
+
+	// implementações Go dos services (ver linkGRPCImpls)
+	Impls  []GRPCImpl `json:"impls,omitempty"`
+	NoImpl []string   `json:"services_without_impl,omitempty"`
+
+	Owners []string `json:"owners,omitempty"` // CODEOWNERS do arquivo
+
+	serviceRPCs map[string][]string
+	goPackage   string // import path de option go_package (sem o ";nome")
 }
 
 // Decision representa uma ADR/decisão técnica detectada.
@@ -98,7 +164,20 @@ type Decision struct {
 
 // Scan executa a varredura e devolve um *Summary pronto para renderização.
 func Scan(ctx context.Context, cfg Config) (*Summary, error) {
-	matcher := files.NewGitIgnoreMatcher(cfg.Root)
+	// -rev: tudo (walk, .gitignore, coletores, árvore) lê o snapshot do commit
+	var (
+		snap   *files.GitFS
+		source *files.Source // nil: disco
+	)
+	if cfg.Rev != "" {
+		var err error
+		if snap, err = files.NewGitFS(ctx, cfg.Root, cfg.Rev); err != nil {
+			return nil, err
+		}
+		defer func() { _ = snap.Close() }()
+		source = files.NewSource(cfg.Root, snap)
+	}
+	matcher := files.NewGitIgnoreMatcher(source, cfg.Root)
 
 	if cfg.Threads <= 0 {
 		cfg.Threads = runtime.NumCPU()
@@ -107,14 +186,21 @@ func Scan(ctx context.Context, cfg Config) (*Summary, error) {
 		Root:            cfg.Root,
 		GeneratedAt:     time.Now(),
 		TechStats:       map[string]int{},
+		FileClasses:     map[string]map[string]int{},
+		SkipGenerated:   cfg.SkipGenerated,
+		LargeAssetBytes: cfg.LargeAssetBytes,
 		ReadmeSummaries: map[string]ReadmeSummary{},
 	}
+	if snap != nil {
+		sum.Rev = cfg.Rev
+		sum.Commit = snap.Commit
+	}
 	includeGlobs := splitCSV(cfg.IncludeGlobsCSV)
 	excludeGlobs := append(files.DefaultIgnore(), splitCSV(cfg.ExcludeGlobsCSV)...)
 
 	// Walk
 	var paths []string
-	err := filepath.WalkDir(cfg.Root, func(path string, d os.DirEntry, err error) error {
+	err := source.WalkDir(cfg.Root, func(path string, d os.DirEntry, err error) error {
 		if err != nil {
 			return nil // skip errors
 		}
@@ -150,9 +236,41 @@ func Scan(ctx context.Context, cfg Config) (*Summary, error) {
 		return nil, err
 	}
 
+	lfsAttrs, lfsPatterns := loadLFSAttributes(source, cfg.Root, paths)
+
+	// -base: coletores só nos arquivos alterados e vizinhos; o panorama continua completo
+	var focus map[string]bool
+	if cfg.Base != "" {
+		ch, err := collectChanges(ctx, cfg.Root, cfg.Base, snap)
+		if err != nil {
+			return nil, err
+		}
+		focus = ch.focus(paths)
+		ch.diffContents(ctx, source, cfg.Root)
+		sum.Changes = ch
+	}
+
 	// Concurrent process files
+	var (
+		stepDefs     []StepDef
+		testFacts    []goTestFacts
+		pyFrags      []PythonProject
+		jsFrags      []JSPackage
+		crates       []RustCrate
+		cargoWS      []RustWorkspace
+		jvmFrags     []JVMProject
+		eventFacts   []goEventFacts
+		routeFacts   []goRouteFacts
+		serverFacts  []goServerFacts
+		diFacts      []goDIFacts
+		codegenFacts []goCodegenFacts
+		goPkgs       = map[string]*goPackage{}
+		locs         []FileLOC
+		lfsAssets    []LFSAsset
+	)
 	sem := make(chan struct{}, cfg.Threads)
 	var mu sync.Mutex
+	skipped := map[string]bool{} // com -skip-generated, arquivos gerados
 	var wg sync.WaitGroup
 loop:
 	for _, p := range paths {
@@ -170,22 +288,159 @@ loop:
 
 			full := filepath.Join(cfg.Root, p)
 			lower := strings.ToLower(p)
+			ext := filepath.Ext(lower)
+			if ext == "" && strings.Contains(lower, "dockerfile") {
+				ext = ".dockerfile"
+			}
+
+			var size int64
+			if fi, err := source.Stat(full); err == nil {
+				size = fi.Size()
+			}
+
+			// Git LFS: pointer (só o pointer no working tree) ou conteúdo já baixado
+			if oid, lfsSize, ok := parseLFSPointer(source, full, size); ok {
+				mu.Lock()
+				lfsAssets = append(lfsAssets, LFSAsset{File: p, Size: lfsSize, OID: oid, Pointer: true})
+				mu.Unlock()
+				return
+			}
+			if lfsAttrs.tracked(p) {
+				mu.Lock()
+				lfsAssets = append(lfsAssets, LFSAsset{File: p, Size: size})
+				mu.Unlock()
+			} else if size >= cfg.LargeAssetBytes && cfg.LargeAssetBytes > 0 {
+				mu.Lock()
+				sum.LargeAssets = append(sum.LargeAssets, LargeAsset{File: p, Size: size})
+				mu.Unlock()
+			}
+
+			if bin, err := source.IsBinary(full); err == nil && bin {
+				mu.Lock()
+				sum.Binaries.Files++
+				sum.Binaries.Bytes += size
+				mu.Unlock()
+				return
+			}
+
+			class := classifyFile(source, full, p)
+			if class != "" {
+				mu.Lock()
+				if sum.FileClasses[class] == nil {
+					sum.FileClasses[class] = map[string]int{}
+				}
+				sum.FileClasses[class][ext]++
+				skip := class == classGenerated && cfg.SkipGenerated
+				if skip {
+					skipped[p] = true
+				}
+				mu.Unlock()
+				if skip {
+					return
+				}
+			}
 
 			switch {
+			case focus != nil && !focus[p]:
+				// fora do foco de -base: só entra nas estatísticas
 			case strings.HasSuffix(lower, "go.mod"):
-				if gm, err := parseGoMod(full); err == nil {
+				if gm, err := parseGoMod(source, full); err == nil {
 					mu.Lock()
 					sum.GoModules = append(sum.GoModules, *gm)
 					mu.Unlock()
 				}
+			case strings.HasSuffix(lower, ".go"):
+				// um parse por arquivo; cada analisador lê o mesmo AST
+				if gs, err := parseGoSource(source, full, p); err == nil {
+					defs := stepDefsFromSource(gs) // godog: ctx.Step(`^...$`, fn)
+					tf := analyzeGoTests(gs)
+					var consts map[string]string
+					if !gs.IsTest() {
+						consts = gs.stringConsts()
+					}
+					ef := eventFactsFromSource(gs, consts) // nats/kafka/amqp publish & subscribe
+					rf := routeFactsFromSource(gs, consts) // net/http, chi, gin, echo, fiber, gorilla
+					sf := serverFactsFromSource(gs)        // structs/métodos para ligar services gRPC
+					df := diFactsFromSource(gs)            // fx/wire: módulos, providers e injetores
+					cf := codegenFactsFromSource(gs)       // go:generate, go:embed, go:build, "Code generated"
+					mu.Lock()
+					if !gs.IsTest() {
+						addGoPackageFile(goPkgs, gs, consts)
+					}
+					stepDefs = append(stepDefs, defs...)
+					testFacts = append(testFacts, tf)
+					eventFacts = append(eventFacts, ef)
+					routeFacts = append(routeFacts, rf)
+					serverFacts = append(serverFacts, sf)
+					diFacts = append(diFacts, df)
+					codegenFacts = append(codegenFacts, cf)
+					mu.Unlock()
+				}
+			case notableConfigKind(lower) != "":
+				if nc, err := parseNotableConfig(source, full, p, notableConfigKind(lower), cfg.MaxFileBytes); err == nil {
+					mu.Lock()
+					sum.NotableConfigs = append(sum.NotableConfigs, *nc)
+					mu.Unlock()
+				}
+			case pythonManifestKind(lower) != "":
+				if pp, err := parsePythonManifest(source, full, p, pythonManifestKind(lower), cfg.MaxFileBytes); err == nil {
+					mu.Lock()
+					pyFrags = append(pyFrags, *pp)
+					mu.Unlock()
+				}
+			case filepath.Base(lower) == "cargo.toml":
+				if rc, ws, err := parseCargoToml(source, full, p, cfg.MaxFileBytes); err == nil {
+					mu.Lock()
+					if rc != nil {
+						crates = append(crates, *rc)
+					}
+					if ws != nil {
+						cargoWS = append(cargoWS, *ws)
+					}
+					mu.Unlock()
+				}
+			case jvmBuildKind(lower) != "":
+				if jp, err := parseJVMBuild(source, full, p, jvmBuildKind(lower), cfg.MaxFileBytes); err == nil {
+					mu.Lock()
+					jvmFrags = append(jvmFrags, *jp)
+					mu.Unlock()
+				}
+			case isGraphQLFile(lower):
+				if gs, err := parseGraphQL(source, full, p, cfg.MaxFileBytes); err == nil {
+					mu.Lock()
+					sum.GraphQLSchemas = append(sum.GraphQLSchemas, *gs)
+					mu.Unlock()
+				}
+			case isGqlgenConfig(lower):
+				if gc, err := parseGqlgenConfig(source, full, p, cfg.MaxFileBytes); err == nil {
+					mu.Lock()
+					sum.Gqlgen = append(sum.Gqlgen, *gc)
+					mu.Unlock()
+				}
+			case jsManifestKind(lower) != "":
+				if jp, err := parseJSManifest(source, full, p, jsManifestKind(lower), cfg.MaxFileBytes); err == nil {
+					mu.Lock()
+					jsFrags = append(jsFrags, *jp)
+					mu.Unlock()
+				}
+			case isAPISpecCandidate(source, full, lower):
+				if spec, err := parseOpenAPI(source, cfg.Root, p); err == nil {
+					mu.Lock()
+					sum.APISpecs = append(sum.APISpecs, *spec)
+					mu.Unlock()
+				} else if as, err := parseAsyncAPI(source, cfg.Root, p); err == nil {
+					mu.Lock()
+					sum.AsyncAPIs = append(sum.AsyncAPIs, *as)
+					mu.Unlock()
+				}
 			case strings.HasSuffix(lower, ".proto"):
-				if pi, err := parseProto(full, cfg.MaxFileBytes); err == nil {
+				if pi, err := parseProto(source, full, cfg.MaxFileBytes); err == nil {
 					mu.Lock()
 					sum.Proto = append(sum.Proto, *pi)
 					mu.Unlock()
 				}
 			case filepath.Base(lower) == "makefile" || strings.HasSuffix(lower, ".mk"):
-				if ts, err := parseMakeTargets(full, cfg.MaxFileBytes); err == nil {
+				if ts, err := parseMakeTargets(source, full, cfg.MaxFileBytes); err == nil {
 					mu.Lock()
 					sum.MakeTargets = append(sum.MakeTargets, ts...)
 					mu.Unlock()
@@ -195,13 +450,13 @@ loop:
 				sum.Dockerfiles = append(sum.Dockerfiles, p)
 				mu.Unlock()
 			case strings.HasSuffix(lower, ".sql"):
-				if strings.Contains(lower, "migrat") || strings.Contains(lower, "schema") {
+				if isSQLMigration(lower) {
 					mu.Lock()
 					sum.SQLMigrations = append(sum.SQLMigrations, p)
 					mu.Unlock()
 				}
 			case strings.HasSuffix(lower, ".md") && (strings.Contains(lower, "/docs/decisions/") || strings.Contains(lower, "/adr")):
-				if dec, err := parseDecision(full, cfg.MaxFileBytes); err == nil {
+				if dec, err := parseDecision(source, full, cfg.MaxFileBytes); err == nil {
 					mu.Lock()
 					sum.Decisions = append(sum.Decisions, *dec)
 					mu.Unlock()
@@ -215,7 +470,7 @@ loop:
 				sum.Licenses = append(sum.Licenses, p)
 				mu.Unlock()
 			case filepath.Base(lower) == "readme.md":
-				if rs, err := parseReadmeSummary(full, cfg.MaxFileBytes); err == nil {
+				if rs, err := parseReadmeSummary(source, full, cfg.MaxFileBytes); err == nil {
 					mu.Lock()
 					sum.Readmes = append(sum.Readmes, p)
 					sum.ReadmeSummaries[p] = *rs
@@ -226,11 +481,15 @@ loop:
 					mu.Unlock()
 				}
 			case strings.HasSuffix(lower, ".feature"):
+				gf, gerr := parseGherkin(source, full, p, cfg.MaxFileBytes)
 				mu.Lock()
 				if sum.TestCoverage == nil {
 					sum.TestCoverage = &CoverageSummary{}
 				}
 				sum.TestCoverage.BDD.FeatureFiles++
+				if gerr == nil {
+					sum.TestCoverage.BDD.Specs = append(sum.TestCoverage.BDD.Specs, *gf)
+				}
 				mu.Unlock()
 
 			case filepath.Base(lower) == "coverage.out" || strings.HasSuffix(lower, ".coverprofile") || strings.HasSuffix(lower, "coverage.txt"):
@@ -249,28 +508,44 @@ loop:
 				sum.TestCoverage.BDD.Reports = append(sum.TestCoverage.BDD.Reports, p)
 				mu.Unlock()
 
-			case strings.HasSuffix(lower, ".xml") && (strings.Contains(lower, "junit") || strings.Contains(lower, "cucumber")):
+			case isJUnitReport(lower) || isGoTestJSONReport(lower):
+				// junit*.xml / TEST-*.xml / gotestsum --jsonfile: resultados de execução, não cobertura
 				mu.Lock()
-				if sum.TestCoverage == nil {
-					sum.TestCoverage = &CoverageSummary{}
+				if sum.TestResults == nil {
+					sum.TestResults = &TestResults{}
 				}
-				sum.TestCoverage.BDD.Reports = append(sum.TestCoverage.BDD.Reports, p)
+				sum.TestResults.Reports = append(sum.TestResults.Reports, p)
 				mu.Unlock()
 
 			}
 
-			// tech stats quick
-			ext := filepath.Ext(lower)
-			if ext == "" && strings.Contains(lower, "dockerfile") {
-				ext = ".dockerfile"
+			// tech stats quick (só código escrito à mão)
+			if class == "" {
+				loc, ok := countFileLOC(source, full, p)
+				mu.Lock()
+				sum.TechStats[ext]++
+				if ok {
+					locs = append(locs, loc)
+				}
+				mu.Unlock()
 			}
-			mu.Lock()
-			sum.TechStats[ext]++
-			mu.Unlock()
 		}()
 	}
 	wg.Wait()
 
+	// Com -skip-generated, os gerados somem também das etapas de consolidação e da árvore.
+	// Binários ficam: só não passam pelos coletores de texto acima (//go:embed de
+	// imagens e fontes, testdata, CODEOWNERS e histórico ainda os veem).
+	if len(skipped) > 0 {
+		kept := paths[:0]
+		for _, p := range paths {
+			if !skipped[p] {
+				kept = append(kept, p)
+			}
+		}
+		paths = kept
+	}
+
 	// Consolidar cobertura (Go + BDD) se houver insumos
 	if sum.TestCoverage != nil {
 		// 1) Perfis de cobertura do Go (coverage.out / coverprofile / coverage.txt)
@@ -282,48 +557,110 @@ loop:
 			}
 		}
 		if len(goSources) > 0 {
-			total, covered := 0, 0
-			for _, path := range goSources {
-				t, c := parseGoCoverProfile(path) // heurística que soma statements cobertos/total
-				total += t
-				covered += c
-			}
-			if total > 0 {
-				sum.TestCoverage.HasGoProfile = true
-				sum.TestCoverage.TotalStmts = total
-				sum.TestCoverage.CoveredStmts = covered
-				sum.TestCoverage.Percent = float64(covered) * 100.0 / float64(total)
-			}
+			// mescla por bloco (mode-aware) e quebra por pacote/arquivo
+			consolidateGoCoverage(source, sum, cfg.Root, goSources)
 		}
 
-		// 2) BDD: se houver cucumber JSON, agregue contagens de features/cenários/passos
-		if len(sum.TestCoverage.BDD.Reports) > 0 {
-			var f, sc, st int
-			for _, rel := range sum.TestCoverage.BDD.Reports {
-				ff, ss, tt := parseCucumberJSON(filepath.Join(cfg.Root, rel))
-				f += ff
-				sc += ss
-				st += tt
+		// 2) BDD: se houver cucumber JSON, agregue contagens e status por feature
+		bdd := &sum.TestCoverage.BDD
+		for _, rel := range bdd.Reports {
+			rep := parseCucumberJSON(source, filepath.Join(cfg.Root, rel))
+			if rep == nil {
+				continue
 			}
-			if f+sc+st > 0 {
-				sum.TestCoverage.BDD.Features = f
-				sum.TestCoverage.BDD.Scenarios = sc
-				sum.TestCoverage.BDD.Steps = st
+			bdd.Features += len(rep.Features)
+			bdd.Scenarios += rep.Scenarios
+			bdd.Steps += rep.Steps
+			bdd.FeatureResults = append(bdd.FeatureResults, rep.Features...)
+			bdd.FailingScenarios = append(bdd.FailingScenarios, rep.Failing...)
+			for _, fr := range rep.Features {
+				bdd.Passed += fr.Passed
+				bdd.Failed += fr.Failed
+				bdd.Skipped += fr.Skipped
+				bdd.Pending += fr.Pending
+				bdd.Undefined += fr.Undefined
 			}
 		}
 	}
 
+	if sum.TestCoverage != nil {
+		specs := sum.TestCoverage.BDD.Specs
+		sort.Slice(specs, func(i, j int) bool { return specs[i].File < specs[j].File })
+		// 3) BDD: casa passos dos .feature com as step definitions godog
+		matchStepDefinitions(&sum.TestCoverage.BDD, stepDefs)
+	}
+
+	// Inventário estático de testes Go (independe de coverprofile)
+	sum.Tests = buildTestInventory(testFacts, paths)
+
+	// Grafo interno de módulos Go (require/replace entre módulos do repo)
+	linkGoModules(sum.GoModules)
+
+	// Crates Rust: membros de workspace, tipo (lib/bin/proc-macro) e grafo interno
+	sum.RustCrates, sum.RustWorkspaces = consolidateRust(crates, cargoWS, paths)
+
+	// Projetos Python: um por diretório, mesclando pyproject/setup.cfg/Pipfile/requirements
+	sum.PythonProjects = mergePythonProjects(pyFrags, paths)
+
+	// Pacotes JS/TS: workspaces, lockfiles, tsconfig paths e grafo interno
+	sum.JSPackages = consolidateJSPackages(source, jsFrags, cfg.Root, paths)
+
+	// GraphQL: raízes (schema { query: X }) podem estar em outro arquivo; gqlgen -> schemas/resolvers
+	resolveGraphQLRoots(sum.GraphQLSchemas)
+	linkGqlgen(sum.Gqlgen, sum.GraphQLSchemas, paths)
+
+	// Pacotes Go do repo (imports e constantes) para os coletores abaixo
+	goIndex := newGoPackageIndex(goPkgs, sum.GoModules, cfg.Root)
+
+	// Services gRPC -> tipos Go que os implementam
+	linkGRPCImpls(sum.Proto, serverFacts, goIndex)
+
+	// Fluxos de eventos: subjects do código Go (constantes entre pacotes) + canais AsyncAPI
+	sort.Slice(sum.AsyncAPIs, func(i, j int) bool { return sum.AsyncAPIs[i].File < sum.AsyncAPIs[j].File })
+	sum.EventFlows = buildEventFlows(eventFacts, sum.AsyncAPIs, goIndex)
+
+	// Rotas HTTP declaradas no código, por binário (pacotes main e seus imports locais)
+	sum.HTTPEndpoints = buildHTTPEndpoints(routeFacts, goIndex)
+
+	// Injeção de dependências (fx/wire), por binário
+	sum.DI = buildDIGraphs(diFacts, goIndex)
+
+	// Geração de código: diretivas, arquivos gerados e de onde vêm
+	sum.CodeGen = buildCodeGen(codegenFacts, paths)
+
+	// Builds JVM (Maven/Gradle)
+	sum.JVMProjects = mergeJVMProjects(jvmFrags)
+
+	// Resultados de testes (JUnit/xUnit, go test -json)
+	if sum.TestResults != nil {
+		sort.Strings(sum.TestResults.Reports)
+		consolidateTestResults(source, sum.TestResults, cfg.Root)
+	}
+
+	sum.LanguageStats = buildLanguageStats(locs)
+	sum.LFS = buildLFS(lfsAssets, lfsPatterns)
+	sort.Slice(sum.LargeAssets, func(i, j int) bool {
+		if sum.LargeAssets[i].Size != sum.LargeAssets[j].Size {
+			return sum.LargeAssets[i].Size > sum.LargeAssets[j].Size
+		}
+		return sum.LargeAssets[i].File < sum.LargeAssets[j].File
+	})
+
 	// Build pruned tree
-	sum.Tree = buildTree(cfg.Root, cfg.TreeDepth, excludeGlobs)
+	sum.Tree = buildTree(source, cfg.Root, cfg.TreeDepth, excludeGlobs, skipped)
+	sum.History = collectHistory(ctx, cfg.Root, cfg.HistoryDays, paths, snap)
+	sum.Ownership = buildOwnership(source, cfg.Root, paths, cfg.TreeDepth, sum.GoModules, sum.Proto)
 
 	// Sort outputs
 	sort.Slice(sum.GoModules, func(i, j int) bool { return sum.GoModules[i].Path < sum.GoModules[j].Path })
+	sort.Slice(sum.APISpecs, func(i, j int) bool { return sum.APISpecs[i].File < sum.APISpecs[j].File })
 	sort.Strings(sum.MakeTargets)
 	sort.Strings(sum.Dockerfiles)
 	sort.Strings(sum.SQLMigrations)
 	sort.Strings(sum.EnvExamples)
 	sort.Strings(sum.Licenses)
 	sort.Strings(sum.Readmes)
+	sort.Slice(sum.NotableConfigs, func(i, j int) bool { return sum.NotableConfigs[i].File < sum.NotableConfigs[j].File })
 
 	return sum, nil
 }
@@ -343,50 +680,130 @@ func splitCSV(s string) []string {
 	return out
 }
 
-func parseGoMod(path string) (*GoModule, error) {
-	data, err := os.ReadFile(path)
+func parseGoMod(source *files.Source, path string) (*GoModule, error) {
+	data, err := source.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 	lines := strings.Split(string(data), "\n")
 	gm := &GoModule{Path: path}
+	block := "" // require/replace em bloco: "require (" ... ")"
 	for _, ln := range lines {
 		ln = strings.TrimSpace(ln)
+		if i := strings.Index(ln, "//"); i >= 0 {
+			ln = strings.TrimSpace(ln[:i])
+		}
+		if block != "" {
+			if ln == ")" {
+				block = ""
+				continue
+			}
+			gm.addDirective(block, ln)
+			continue
+		}
 		if strings.HasPrefix(ln, "module ") {
 			gm.Module = strings.TrimSpace(strings.TrimPrefix(ln, "module"))
 		}
-		if strings.HasPrefix(ln, "require ") {
-			rest := strings.TrimSpace(strings.TrimPrefix(ln, "require"))
-			rest = strings.Trim(rest, "()")
-			for _, r := range strings.Split(rest, "\n") {
-				r = strings.TrimSpace(r)
-				if r == "" || strings.HasPrefix(r, "//") {
-					continue
-				}
-				parts := strings.Fields(r)
-				if len(parts) >= 1 {
-					gm.Requires = append(gm.Requires, parts[0])
-				}
+		for _, d := range []string{"require", "replace"} {
+			if !strings.HasPrefix(ln, d+" ") && !strings.HasPrefix(ln, d+"(") {
+				continue
+			}
+			rest := strings.TrimSpace(strings.TrimPrefix(ln, d))
+			if rest == "(" {
+				block = d
+				continue
 			}
+			gm.addDirective(d, strings.Trim(rest, "()"))
 		}
 	}
 	return gm, nil
 }
 
+// addDirective registra uma linha de require ou replace (só replaces locais
+// importam: apontam para outro módulo do repositório).
+func (gm *GoModule) addDirective(kind, ln string) {
+	parts := strings.Fields(ln)
+	if len(parts) == 0 {
+		return
+	}
+	switch kind {
+	case "require":
+		gm.Requires = append(gm.Requires, parts[0])
+	case "replace":
+		for i, p := range parts {
+			if p == "=>" && i+1 < len(parts) {
+				if t := parts[i+1]; strings.HasPrefix(t, ".") || strings.HasPrefix(t, "/") {
+					if gm.localReplaces == nil {
+						gm.localReplaces = map[string]string{}
+					}
+					gm.localReplaces[parts[0]] = t
+				}
+			}
+		}
+	}
+}
+
+// linkGoModules preenche GoModule.Internal com os módulos do próprio
+// repositório exigidos (por caminho de módulo ou replace local).
+func linkGoModules(mods []GoModule) {
+	byModule := map[string]bool{}
+	byDir := map[string]string{}
+	for _, m := range mods {
+		byModule[m.Module] = true
+		byDir[filepath.Dir(m.Path)] = m.Module
+	}
+	for i := range mods {
+		m := &mods[i]
+		var internal []string
+		for _, r := range m.Requires {
+			if r != m.Module && byModule[r] {
+				internal = append(internal, r)
+			}
+		}
+		for mod, target := range m.localReplaces {
+			if !filepath.IsAbs(target) {
+				target = filepath.Join(filepath.Dir(m.Path), target)
+			}
+			if name, ok := byDir[filepath.Clean(target)]; ok && name != m.Module {
+				internal = append(internal, name)
+			} else if byModule[mod] && mod != m.Module {
+				internal = append(internal, mod)
+			}
+		}
+		m.Internal = dedupeSorted(internal)
+	}
+}
+
+// isSQLMigration reconhece migrations/schemas SQL pelo caminho.
+func isSQLMigration(lower string) bool {
+	return strings.HasSuffix(lower, ".sql") && (strings.Contains(lower, "migrat") || strings.Contains(lower, "schema"))
+}
+
 // >>> Evitar conflito com built-in max (Go 1.21+)
-func parseProto(path string, maxBytes int64) (*ProtoInfo, error) {
-	head, err := files.ReadHead(path, maxBytes)
+func parseProto(source *files.Source, path string, maxBytes int64) (*ProtoInfo, error) {
+	head, err := source.ReadHead(path, maxBytes)
 	if err != nil {
 		return nil, err
 	}
-	pi := &ProtoInfo{File: path}
+	return parseProtoSource(path, head), nil
+}
+
+// parseProtoSource extrai package, go_package, services e RPCs do texto de um .proto.
+func parseProtoSource(path, head string) *ProtoInfo {
+	pi := &ProtoInfo{File: path, serviceRPCs: map[string][]string{}}
+	svc := ""
 	for _, ln := range strings.Split(head, "\n") {
 		ln = strings.TrimSpace(ln)
 		if strings.HasPrefix(ln, "package ") {
 			pi.Package = strings.TrimSuffix(strings.TrimSpace(strings.TrimPrefix(ln, "package")), ";")
 		}
+		if strings.HasPrefix(ln, "option go_package") {
+			if q := strings.Split(ln, `"`); len(q) >= 3 {
+				pi.goPackage, _, _ = strings.Cut(q[1], ";")
+			}
+		}
 		if strings.HasPrefix(ln, "service ") {
-			svc := strings.TrimSpace(strings.TrimPrefix(ln, "service"))
+			svc = strings.TrimSpace(strings.TrimPrefix(ln, "service"))
 			svc = strings.TrimSpace(strings.SplitN(svc, "{", 2)[0])
 			pi.Services = append(pi.Services, svc)
 		}
@@ -394,16 +811,22 @@ func parseProto(path string, maxBytes int64) (*ProtoInfo, error) {
 			rpc := strings.TrimSpace(strings.TrimPrefix(ln, "rpc"))
 			rpc = strings.TrimSpace(strings.SplitN(rpc, "(", 2)[0])
 			pi.RPCs = append(pi.RPCs, rpc)
+			pi.serviceRPCs[svc] = append(pi.serviceRPCs[svc], rpc)
 		}
 	}
-	return pi, nil
+	return pi
 }
 
-func parseMakeTargets(path string, maxBytes int64) ([]string, error) {
-	head, err := files.ReadHead(path, maxBytes)
+func parseMakeTargets(source *files.Source, path string, maxBytes int64) ([]string, error) {
+	head, err := source.ReadHead(path, maxBytes)
 	if err != nil {
 		return nil, err
 	}
+	return makeTargetsFromSource(head), nil
+}
+
+// makeTargetsFromSource lista os alvos declarados no texto de um Makefile.
+func makeTargetsFromSource(head string) []string {
 	var targets []string
 	for _, ln := range strings.Split(head, "\n") {
 		ln = strings.TrimSpace(ln)
@@ -418,11 +841,11 @@ func parseMakeTargets(path string, maxBytes int64) ([]string, error) {
 			}
 		}
 	}
-	return targets, nil
+	return targets
 }
 
-func parseDecision(path string, maxBytes int64) (*Decision, error) {
-	head, err := files.ReadHead(path, maxBytes)
+func parseDecision(source *files.Source, path string, maxBytes int64) (*Decision, error) {
+	head, err := source.ReadHead(path, maxBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -445,8 +868,8 @@ func parseDecision(path string, maxBytes int64) (*Decision, error) {
 	return &Decision{File: path, Title: title, Summary: summary}, nil
 }
 
-func parseReadmeSummary(path string, maxBytes int64) (*ReadmeSummary, error) {
-	head, err := files.ReadHead(path, maxBytes)
+func parseReadmeSummary(source *files.Source, path string, maxBytes int64) (*ReadmeSummary, error) {
+	head, err := source.ReadHead(path, maxBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -534,36 +957,6 @@ func parseReadmeSummary(path string, maxBytes int64) (*ReadmeSummary, error) {
 	return rs, nil
 }
 
-// parseGoCoverProfile lê um arquivo coverprofile (formato go tool cover -coverprofile)
-// e soma statements totais/cobertos usando a heurística: se count>0 => cobre numStatements.
-func parseGoCoverProfile(path string) (total int, covered int) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return 0, 0
-	}
-	lines := strings.Split(string(data), "\n")
-	for _, ln := range lines {
-		ln = strings.TrimSpace(ln)
-		if ln == "" || strings.HasPrefix(ln, "mode:") {
-			continue
-		}
-		// formato: file.go:line1.col1,line2.col2 numStatements count
-		// ex: internal/x.go:14.2,19.3 3 1
-		parts := strings.Fields(ln)
-		if len(parts) < 3 {
-			continue
-		}
-		// parts[0] = "file:span", parts[1] = numStatements, parts[2] = count
-		numStmts := atoiSafe(parts[1])
-		count := atoiSafe(parts[2])
-		total += numStmts
-		if count > 0 {
-			covered += numStmts
-		}
-	}
-	return
-}
-
 func atoiSafe(s string) int {
 	n := 0
 	for _, r := range s {
@@ -575,54 +968,14 @@ func atoiSafe(s string) int {
 	return n
 }
 
-// parseCucumberJSON agrega features/cenários/passos de um arquivo cucumber.json (godog/cucumber).
-// Forma resiliente: ignora campos ausentes e erros de parse.
-func parseCucumberJSON(path string) (features, scenarios, steps int) {
-	data, err := os.ReadFile(path)
-	if err != nil || len(data) == 0 {
-		return
-	}
-	// cucumber.json costuma ser um array de Features
-	// Estrutura mínima parcial para deserialização resiliente
-	type step struct{}
-	type element struct {
-		Steps []step `json:"steps"`
-		Type  string `json:"type"` // "scenario" etc.
-	}
-	type feature struct {
-		Elements []element `json:"elements"`
-	}
-	var arr []feature
-	if err := json.Unmarshal(data, &arr); err != nil {
-		// alguns geradores usam objeto raiz { "features": [...] }
-		var root struct {
-			Features []feature `json:"features"`
-		}
-		if err2 := json.Unmarshal(data, &root); err2 != nil {
-			return
-		}
-		arr = root.Features
-	}
-	for _, f := range arr {
-		features++
-		for _, el := range f.Elements {
-			if strings.EqualFold(el.Type, "scenario") || el.Type == "" {
-				scenarios++
-			}
-			steps += len(el.Steps)
-		}
-	}
-	return
-}
-
 type treeNode struct {
 	Name     string
 	IsDir    bool
 	Children []*treeNode
 }
 
-func buildTree(root string, depth int, exclude []string) []string {
-	matcher := files.NewGitIgnoreMatcher(root)
+func buildTree(source *files.Source, root string, depth int, exclude []string, skip map[string]bool) []string {
+	matcher := files.NewGitIgnoreMatcher(source, root)
 	if depth <= 0 {
 		depth = 3
 	}
@@ -633,7 +986,7 @@ func buildTree(root string, depth int, exclude []string) []string {
 		if d == 0 {
 			return node
 		}
-		entries, _ := os.ReadDir(dir)
+		entries, _ := source.ReadDir(dir)
 		for _, e := range entries {
 			if strings.HasPrefix(e.Name(), ".") && e.Name() != ".github" {
 				continue
@@ -652,7 +1005,7 @@ func buildTree(root string, depth int, exclude []string) []string {
 				}
 				node.Children = append(node.Children, walk(path, d-1))
 			} else {
-				if files.MatchAny(exclude, rel) {
+				if files.MatchAny(exclude, rel) || skip[rel] {
 					continue
 				}
 				node.Children = append(node.Children, &treeNode{Name: e.Name(), IsDir: false})
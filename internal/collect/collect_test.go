@@ -0,0 +1,62 @@
+package collect
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Varreduras de -rev e do working tree ao mesmo tempo não podem ler uma a
+// fonte da outra.
+func TestScanConcurrentSources(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git não encontrado")
+	}
+	root := t.TempDir()
+	gitRun := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@t"}, args...)...)
+		cmd.Dir = root
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	gitRun("init", "-q")
+	write("go.mod", "module example.com/m\n\ngo 1.22\n")
+	write("main.go", "package main\n\nfunc main() {}\n")
+	gitRun("add", ".")
+	gitRun("commit", "-q", "-m", "init")
+	write("extra.go", "package main\n\nvar x = 1\n") // só no working tree
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		rev := ""
+		if i%2 == 0 {
+			rev = "HEAD"
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sum, err := Scan(context.Background(), Config{Root: root, MaxFileBytes: 64 << 10, Threads: 2, TreeDepth: 2, Rev: rev})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if got, want := slices.ContainsFunc(sum.Tree, func(l string) bool { return strings.TrimSpace(l) == "extra.go" }), rev == ""; got != want {
+				t.Errorf("Rev=%q: extra.go na árvore = %v, want %v", rev, got, want)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,470 @@
+package collect
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// NotableConfig descreve um arquivo de configuração que molda o repo
+// (lint, build, release, atualização de deps...) e seus ajustes-chave.
+type NotableConfig struct {
+	File     string            `json:"file"`
+	Kind     string            `json:"kind"`     // golangci | editorconfig | tsconfig | goreleaser | renovate | ...
+	Settings map[string]string `json:"settings"` // chave -> valor resumido (listas unidas por ", ")
+}
+
+// notableConfigKind reconhece configs pelo nome do arquivo (caminho em minúsculas).
+func notableConfigKind(lower string) string {
+	base := path.Base(lower)
+	switch {
+	case strings.HasPrefix(base, ".golangci.") || strings.HasPrefix(base, "golangci."):
+		return "golangci"
+	case base == ".editorconfig":
+		return "editorconfig"
+	case (strings.HasPrefix(base, "tsconfig") || strings.HasPrefix(base, "jsconfig")) && strings.HasSuffix(base, ".json"):
+		return "tsconfig"
+	case strings.HasPrefix(base, ".goreleaser.") || strings.HasPrefix(base, "goreleaser."):
+		return "goreleaser"
+	case base == "renovate.json" || base == "renovate.json5" || base == ".renovaterc" || base == ".renovaterc.json":
+		return "renovate"
+	case base == "dependabot.yml" || base == "dependabot.yaml":
+		return "dependabot"
+	case base == ".pre-commit-config.yaml" || base == ".pre-commit-config.yml":
+		return "pre-commit"
+	case base == "codecov.yml" || base == ".codecov.yml" || base == "codecov.yaml" || base == ".codecov.yaml":
+		return "codecov"
+	case base == "buf.yaml" || base == "buf.work.yaml":
+		return "buf"
+	case base == "buf.gen.yaml":
+		return "buf-gen"
+	case base == "sqlc.yaml" || base == "sqlc.yml" || base == "sqlc.json":
+		return "sqlc"
+	case strings.HasPrefix(base, "docker-compose") && (strings.HasSuffix(base, ".yml") || strings.HasSuffix(base, ".yaml")),
+		base == "compose.yml" || base == "compose.yaml":
+		return "docker-compose"
+	case base == ".tool-versions" || base == ".nvmrc" || base == ".node-version" || base == ".python-version" || base == ".go-version":
+		return "tool-versions"
+	case base == ".prettierrc" || strings.HasPrefix(base, ".prettierrc."):
+		return "prettier"
+	case strings.HasPrefix(base, ".eslintrc") && !strings.HasSuffix(base, ".js") && !strings.HasSuffix(base, ".cjs"):
+		return "eslint"
+	}
+	return ""
+}
+
+// parseNotableConfig lê o arquivo e extrai os ajustes relevantes do tipo.
+func parseNotableConfig(source *files.Source, full, rel, kind string, maxBytes int64) (*NotableConfig, error) {
+	head, err := source.ReadHead(full, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	nc := &NotableConfig{File: rel, Kind: kind, Settings: map[string]string{}}
+	set := func(k string, v any) {
+		if s := settingString(v); s != "" {
+			nc.Settings[k] = s
+		}
+	}
+
+	switch kind {
+	case "editorconfig":
+		section := ""
+		for _, ln := range strings.Split(head, "\n") {
+			t := strings.TrimSpace(ln)
+			if t == "" || strings.HasPrefix(t, "#") || strings.HasPrefix(t, ";") {
+				continue
+			}
+			if strings.HasPrefix(t, "[") && strings.HasSuffix(t, "]") {
+				section = t
+				continue
+			}
+			k, v, ok := strings.Cut(t, "=")
+			if !ok {
+				continue
+			}
+			k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+			if section == "" {
+				set(k, v)
+				continue
+			}
+			prev := nc.Settings[section]
+			if prev != "" {
+				prev += ", "
+			}
+			nc.Settings[section] = prev + k + "=" + v
+		}
+		return nc, nil
+
+	case "tool-versions":
+		base := path.Base(rel)
+		if base != ".tool-versions" {
+			set(strings.TrimPrefix(strings.TrimSuffix(base, "-version"), "."), strings.TrimSpace(head))
+			return nc, nil
+		}
+		for _, ln := range strings.Split(head, "\n") {
+			f := strings.Fields(ln)
+			if len(f) >= 2 && !strings.HasPrefix(f[0], "#") {
+				set(f[0], strings.Join(f[1:], " "))
+			}
+		}
+		return nc, nil
+	}
+
+	doc, err := decodeConfigDoc(head, rel)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case "golangci":
+		set("version", getPath(doc, "version"))
+		set("run.go", getPath(doc, "run.go"))
+		set("run.timeout", getPath(doc, "run.timeout"))
+		set("linters.default", getPath(doc, "linters.default")) // v2
+		if b, ok := getPath(doc, "linters.enable-all").(bool); ok && b {
+			set("linters.default", "all") // v1
+		}
+		if b, ok := getPath(doc, "linters.disable-all").(bool); ok && b {
+			set("linters.default", "none") // v1
+		}
+		set("linters.enable", getPath(doc, "linters.enable"))
+		set("linters.disable", getPath(doc, "linters.disable"))
+		set("formatters.enable", getPath(doc, "formatters.enable"))
+		if s, ok := getPath(doc, "linters.settings").(map[string]any); ok {
+			set("linters.settings", sortedKeys(s))
+		} else if s, ok := getPath(doc, "linters-settings").(map[string]any); ok {
+			set("linters.settings", sortedKeys(s))
+		}
+
+	case "tsconfig":
+		set("extends", getPath(doc, "extends"))
+		for _, k := range []string{"strict", "target", "module", "moduleResolution", "noImplicitAny", "strictNullChecks",
+			"noUncheckedIndexedAccess", "exactOptionalPropertyTypes", "noImplicitReturns", "jsx", "baseUrl", "outDir", "composite"} {
+			set(k, getPath(doc, "compilerOptions."+k))
+		}
+		if p, ok := getPath(doc, "compilerOptions.paths").(map[string]any); ok {
+			set("paths", sortedKeys(p))
+		}
+		if refs, ok := getPath(doc, "references").([]any); ok {
+			var out []string
+			for _, r := range refs {
+				out = append(out, settingString(getPath(r, "path")))
+			}
+			set("references", out)
+		}
+
+	case "goreleaser":
+		set("version", getPath(doc, "version"))
+		if builds, ok := getPath(doc, "builds").([]any); ok {
+			for i, bd := range builds {
+				id := settingString(getPath(bd, "id"))
+				if id == "" {
+					id = fmt.Sprintf("%d", i)
+				}
+				var parts []string
+				if m := settingString(getPath(bd, "main")); m != "" {
+					parts = append(parts, "main="+m)
+				}
+				if v := settingString(getPath(bd, "goos")); v != "" {
+					parts = append(parts, "goos="+v)
+				}
+				if v := settingString(getPath(bd, "goarch")); v != "" {
+					parts = append(parts, "goarch="+v)
+				}
+				if v := settingString(getPath(bd, "env")); v != "" {
+					parts = append(parts, "env="+v)
+				}
+				set("builds."+id, strings.Join(parts, "; "))
+			}
+		}
+		if archives, ok := getPath(doc, "archives").([]any); ok {
+			var formats []string
+			for _, a := range archives {
+				formats = append(formats, settingString(getPath(a, "format")), settingString(getPath(a, "formats")))
+			}
+			set("archives", dedupeSorted(formats))
+		}
+		set("dockers", collectField(getPath(doc, "dockers"), "image_templates"))
+		set("brews", collectField(getPath(doc, "brews"), "name"))
+		set("release", getPath(doc, "release.github.name"))
+
+	case "renovate":
+		set("extends", getPath(doc, "extends"))
+		set("schedule", getPath(doc, "schedule"))
+		set("timezone", getPath(doc, "timezone"))
+		set("automerge", getPath(doc, "automerge"))
+		set("rangeStrategy", getPath(doc, "rangeStrategy"))
+		set("labels", getPath(doc, "labels"))
+		if rules, ok := getPath(doc, "packageRules").([]any); ok {
+			set("packageRules", len(rules))
+		}
+		set("lockFileMaintenance", getPath(doc, "lockFileMaintenance.enabled"))
+
+	case "dependabot":
+		if ups, ok := getPath(doc, "updates").([]any); ok {
+			for _, u := range ups {
+				key := settingString(getPath(u, "package-ecosystem")) + "@" + settingString(getPath(u, "directory"))
+				set(key, getPath(u, "schedule.interval"))
+			}
+		}
+
+	case "pre-commit":
+		if repos, ok := getPath(doc, "repos").([]any); ok {
+			for _, r := range repos {
+				repo := settingString(getPath(r, "repo"))
+				set(strings.TrimPrefix(strings.TrimPrefix(repo, "https://"), "github.com/"), collectField(getPath(r, "hooks"), "id"))
+			}
+		}
+		set("default_stages", getPath(doc, "default_stages"))
+
+	case "codecov":
+		set("project.target", firstNonNil(getPath(doc, "coverage.status.project.default.target"), getPath(doc, "coverage.status.project")))
+		set("patch.target", firstNonNil(getPath(doc, "coverage.status.patch.default.target"), getPath(doc, "coverage.status.patch")))
+		set("range", getPath(doc, "coverage.range"))
+		set("ignore", getPath(doc, "ignore"))
+		set("comment", getPath(doc, "comment.layout"))
+		if flags, ok := getPath(doc, "flags").(map[string]any); ok {
+			set("flags", sortedKeys(flags))
+		}
+
+	case "buf":
+		set("version", getPath(doc, "version"))
+		set("lint.use", getPath(doc, "lint.use"))
+		set("breaking.use", getPath(doc, "breaking.use"))
+		set("deps", getPath(doc, "deps"))
+		set("modules", collectField(getPath(doc, "modules"), "path"))
+		set("directories", getPath(doc, "directories"))
+
+	case "buf-gen":
+		set("version", getPath(doc, "version"))
+		var plugins []string
+		if ps, ok := getPath(doc, "plugins").([]any); ok {
+			for _, p := range ps {
+				name := settingString(firstNonNil(getPath(p, "remote"), getPath(p, "local"), getPath(p, "plugin"), getPath(p, "name")))
+				if out := settingString(getPath(p, "out")); out != "" {
+					name += " → " + out
+				}
+				plugins = append(plugins, name)
+			}
+		}
+		set("plugins", plugins)
+
+	case "sqlc":
+		set("version", getPath(doc, "version"))
+		sqls, _ := getPath(doc, "sql").([]any)
+		for i, s := range sqls {
+			key := fmt.Sprintf("sql[%d]", i)
+			parts := []string{"engine=" + settingString(getPath(s, "engine"))}
+			if v := settingString(getPath(s, "schema")); v != "" {
+				parts = append(parts, "schema="+v)
+			}
+			if v := settingString(getPath(s, "queries")); v != "" {
+				parts = append(parts, "queries="+v)
+			}
+			if v := settingString(firstNonNil(getPath(s, "gen.go.package"), getPath(s, "gen.go.out"))); v != "" {
+				parts = append(parts, "go="+v)
+			}
+			set(key, strings.Join(parts, "; "))
+		}
+
+	case "docker-compose":
+		if svcs, ok := getPath(doc, "services").(map[string]any); ok {
+			for _, name := range sortedKeys(svcs) {
+				svc := svcs[name]
+				desc := settingString(getPath(svc, "image"))
+				if desc == "" && getPath(svc, "build") != nil {
+					desc = "build: " + settingString(firstNonNil(getPath(svc, "build.context"), getPath(svc, "build")))
+				}
+				if ports := settingString(getPath(svc, "ports")); ports != "" {
+					desc += " (ports " + ports + ")"
+				}
+				set("services."+name, desc)
+			}
+		}
+
+	case "prettier":
+		for _, k := range []string{"printWidth", "tabWidth", "useTabs", "semi", "singleQuote", "trailingComma"} {
+			set(k, getPath(doc, k))
+		}
+
+	case "eslint":
+		set("extends", getPath(doc, "extends"))
+		set("plugins", getPath(doc, "plugins"))
+		set("parser", getPath(doc, "parser"))
+		if rules, ok := getPath(doc, "rules").(map[string]any); ok {
+			set("rules", len(rules))
+		}
+	}
+	return nc, nil
+}
+
+// decodeConfigDoc decodifica YAML, TOML ou JSON (com comentários/vírgulas finais).
+func decodeConfigDoc(content, rel string) (any, error) {
+	var doc any
+	low := strings.ToLower(rel)
+	if strings.HasSuffix(low, ".json") || strings.HasSuffix(low, ".json5") || strings.HasSuffix(low, ".renovaterc") ||
+		(strings.HasSuffix(low, ".prettierrc") || strings.HasSuffix(low, ".eslintrc")) && strings.HasPrefix(strings.TrimSpace(content), "{") {
+		if err := json.Unmarshal([]byte(stripJSONC(content)), &doc); err != nil {
+			return nil, err
+		}
+		return doc, nil
+	}
+	if strings.HasSuffix(low, ".toml") {
+		return parseTOML(content), nil
+	}
+	if err := yaml.Unmarshal([]byte(content), &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// stripJSONC remove comentários (// e /* */) e vírgulas finais de JSON "relaxado"
+// (tsconfig.json, renovate.json5), respeitando strings.
+func stripJSONC(s string) string {
+	var b strings.Builder
+	inStr := false
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if inStr {
+			b.WriteByte(c)
+			if c == '\\' && i+1 < len(s) {
+				i++
+				b.WriteByte(s[i])
+			} else if c == '"' {
+				inStr = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inStr = true
+			b.WriteByte(c)
+		case c == '/' && i+1 < len(s) && s[i+1] == '/':
+			for i < len(s) && s[i] != '\n' {
+				i++
+			}
+			if i < len(s) {
+				b.WriteByte('\n')
+			}
+		case c == '/' && i+1 < len(s) && s[i+1] == '*':
+			i += 2
+			for i+1 < len(s) && !(s[i] == '*' && s[i+1] == '/') {
+				i++
+			}
+			i++
+		case c == ',':
+			// vírgula final antes de } ou ]
+			j := i + 1
+			for j < len(s) && strings.ContainsRune(" \t\r\n", rune(s[j])) {
+				j++
+			}
+			if j < len(s) && (s[j] == '}' || s[j] == ']') {
+				continue
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// getPath navega mapas por "a.b.c"; devolve nil se algum nível não existir.
+func getPath(doc any, dotted string) any {
+	cur := doc
+	for _, k := range strings.Split(dotted, ".") {
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur, ok = m[k]
+		if !ok {
+			return nil
+		}
+	}
+	return cur
+}
+
+// collectField junta o campo de cada item de uma lista (ex.: hooks[].id).
+func collectField(list any, field string) []string {
+	items, ok := list.([]any)
+	if !ok {
+		return nil
+	}
+	var out []string
+	for _, it := range items {
+		if s := settingString(getPath(it, field)); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func firstNonNil(vals ...any) any {
+	for _, v := range vals {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]any) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// settingString resume um valor YAML/JSON em uma linha.
+func settingString(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(x)
+	case []string:
+		return strings.Join(x, ", ")
+	case []any:
+		var parts []string
+		for _, it := range x {
+			if s := settingString(it); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]any:
+		return "{" + strings.Join(sortedKeys(x), ", ") + "}"
+	case float64:
+		if x == float64(int64(x)) {
+			return fmt.Sprintf("%d", int64(x))
+		}
+		return fmt.Sprintf("%g", x)
+	default:
+		return fmt.Sprint(x)
+	}
+}
+
+// dedupeSorted remove vazios/duplicados e ordena.
+func dedupeSorted(in []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, s := range in {
+		s = strings.TrimSpace(s)
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,321 @@
+package collect
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// PackageCoverage resume a cobertura de um pacote Go (import path).
+type PackageCoverage struct {
+	Package      string  `json:"package"`
+	TotalStmts   int     `json:"total_stmts"`
+	CoveredStmts int     `json:"covered_stmts"`
+	Percent      float64 `json:"percent"`
+}
+
+// FileCoverage resume a cobertura de um arquivo Go do coverprofile.
+type FileCoverage struct {
+	File         string  `json:"file"` // nome como aparece no profile (import path + arquivo)
+	Package      string  `json:"package"`
+	TotalStmts   int     `json:"total_stmts"`
+	CoveredStmts int     `json:"covered_stmts"`
+	Percent      float64 `json:"percent"`
+}
+
+// UncoveredFunc aponta uma função exportada sem nenhum statement coberto.
+type UncoveredFunc struct {
+	Package string `json:"package"`
+	Func    string `json:"func"` // ex.: "New" ou "(*Server).Start"
+	File    string `json:"file"` // caminho relativo à raiz do repo (se resolvido)
+	Line    int    `json:"line"`
+	Stmts   int    `json:"stmts"`
+}
+
+// maxLeastCovered limita a lista de pacotes menos cobertos.
+const maxLeastCovered = 10
+
+// coverBlock é um bloco do coverprofile já mesclado entre perfis.
+type coverBlock struct {
+	File      string
+	StartLine int
+	EndLine   int
+	NumStmts  int
+	Count     int
+}
+
+// coverProfiles mescla blocos de vários coverprofiles, como `go tool cover`
+// (e gocovmerge) fazem: o mesmo bloco só conta uma vez; em modo "set" o
+// contador vira 0/1, em "count"/"atomic" os contadores são somados.
+type coverProfiles struct {
+	blocks map[string]*coverBlock // chave: "file:span"
+	order  []string
+}
+
+func newCoverProfiles() *coverProfiles {
+	return &coverProfiles{blocks: map[string]*coverBlock{}}
+}
+
+// add lê um arquivo coverprofile e mescla seus blocos; erros de leitura são ignorados.
+func (cp *coverProfiles) add(source *files.Source, path string) {
+	data, err := source.ReadFile(path)
+	if err != nil {
+		return
+	}
+	mode := "set"
+	for _, ln := range strings.Split(string(data), "\n") {
+		ln = strings.TrimSpace(ln)
+		if ln == "" {
+			continue
+		}
+		if strings.HasPrefix(ln, "mode:") {
+			mode = strings.TrimSpace(strings.TrimPrefix(ln, "mode:"))
+			continue
+		}
+		// formato: file.go:line1.col1,line2.col2 numStatements count
+		// ex: internal/x.go:14.2,19.3 3 1
+		parts := strings.Fields(ln)
+		if len(parts) < 3 {
+			continue
+		}
+		colon := strings.LastIndex(parts[0], ":")
+		if colon <= 0 {
+			continue
+		}
+		file, span := parts[0][:colon], parts[0][colon+1:]
+		startEnd := strings.SplitN(span, ",", 2)
+		if len(startEnd) != 2 {
+			continue
+		}
+		numStmts := atoiSafe(parts[1])
+		count := atoiSafe(parts[2])
+
+		key := parts[0]
+		b, ok := cp.blocks[key]
+		if !ok {
+			b = &coverBlock{
+				File:      file,
+				StartLine: atoiSafe(startEnd[0]),
+				EndLine:   atoiSafe(startEnd[1]),
+				NumStmts:  numStmts,
+			}
+			cp.blocks[key] = b
+			cp.order = append(cp.order, key)
+		}
+		if mode == "set" {
+			if count > 0 {
+				b.Count = 1
+			}
+		} else {
+			b.Count += count
+		}
+	}
+}
+
+// consolidateGoCoverage mescla os perfis e preenche totais, pacotes, arquivos,
+// pacotes menos cobertos e funções exportadas sem cobertura.
+func consolidateGoCoverage(source *files.Source, sum *Summary, root string, profiles []string) {
+	cp := newCoverProfiles()
+	for _, p := range profiles {
+		cp.add(source, p)
+	}
+	if len(cp.blocks) == 0 {
+		return
+	}
+
+	tc := sum.TestCoverage
+	fileIdx := map[string]*FileCoverage{}
+	pkgIdx := map[string]*PackageCoverage{}
+	byFile := map[string][]*coverBlock{}
+	total, covered := 0, 0
+	for _, key := range cp.order {
+		b := cp.blocks[key]
+		byFile[b.File] = append(byFile[b.File], b)
+
+		pkg := path.Dir(b.File)
+		fc, ok := fileIdx[b.File]
+		if !ok {
+			fc = &FileCoverage{File: b.File, Package: pkg}
+			fileIdx[b.File] = fc
+		}
+		pc, ok := pkgIdx[pkg]
+		if !ok {
+			pc = &PackageCoverage{Package: pkg}
+			pkgIdx[pkg] = pc
+		}
+		total += b.NumStmts
+		fc.TotalStmts += b.NumStmts
+		pc.TotalStmts += b.NumStmts
+		if b.Count > 0 {
+			covered += b.NumStmts
+			fc.CoveredStmts += b.NumStmts
+			pc.CoveredStmts += b.NumStmts
+		}
+	}
+	if total == 0 {
+		return
+	}
+	tc.HasGoProfile = true
+	tc.TotalStmts = total
+	tc.CoveredStmts = covered
+	tc.Percent = percent(covered, total)
+
+	for _, fc := range fileIdx {
+		fc.Percent = percent(fc.CoveredStmts, fc.TotalStmts)
+		tc.Files = append(tc.Files, *fc)
+	}
+	for _, pc := range pkgIdx {
+		pc.Percent = percent(pc.CoveredStmts, pc.TotalStmts)
+		tc.Packages = append(tc.Packages, *pc)
+	}
+	sort.Slice(tc.Files, func(i, j int) bool { return tc.Files[i].File < tc.Files[j].File })
+	sort.Slice(tc.Packages, func(i, j int) bool { return tc.Packages[i].Package < tc.Packages[j].Package })
+
+	// pacotes menos cobertos (com statements), desempate por volume descoberto
+	least := make([]PackageCoverage, 0, len(tc.Packages))
+	for _, pc := range tc.Packages {
+		if pc.TotalStmts > 0 && pc.CoveredStmts < pc.TotalStmts {
+			least = append(least, pc)
+		}
+	}
+	sort.SliceStable(least, func(i, j int) bool {
+		if least[i].Percent != least[j].Percent {
+			return least[i].Percent < least[j].Percent
+		}
+		return least[i].TotalStmts-least[i].CoveredStmts > least[j].TotalStmts-least[j].CoveredStmts
+	})
+	if len(least) > maxLeastCovered {
+		least = least[:maxLeastCovered]
+	}
+	for _, pc := range least {
+		tc.LeastCovered = append(tc.LeastCovered, pc.Package)
+	}
+
+	// funções exportadas sem cobertura: exige localizar o fonte pelo module path
+	var names []string
+	for f := range byFile {
+		names = append(names, f)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		local := resolveGoImportFile(sum.GoModules, root, name)
+		if local == "" {
+			continue
+		}
+		tc.UncoveredFuncs = append(tc.UncoveredFuncs, uncoveredExportedFuncs(source, root, local, name, byFile[name])...)
+	}
+}
+
+// resolveGoImportFile traduz "module/path/pkg/file.go" para um caminho relativo
+// à raiz usando o go.mod de prefixo mais longo. Retorna "" se não encontrar.
+func resolveGoImportFile(mods []GoModule, root, importFile string) string {
+	best, bestDir := "", ""
+	for _, m := range mods {
+		mod := strings.TrimSpace(m.Module)
+		if mod == "" || len(mod) <= len(best) {
+			continue
+		}
+		if importFile == mod || strings.HasPrefix(importFile, mod+"/") {
+			best = mod
+			bestDir = filepath.Dir(m.Path)
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	// o próprio module path é o pacote da raiz do módulo
+	rest := strings.TrimPrefix(strings.TrimPrefix(importFile, best), "/")
+	full := filepath.Join(bestDir, filepath.FromSlash(rest))
+	if !filepath.IsAbs(full) {
+		full = filepath.Join(root, full)
+	}
+	rel, err := filepath.Rel(root, full)
+	if err != nil {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+// uncoveredExportedFuncs parseia o arquivo e devolve as funções exportadas
+// cujos blocos somam statements mas nenhum foi executado.
+func uncoveredExportedFuncs(source *files.Source, root, rel, importFile string, blocks []*coverBlock) []UncoveredFunc {
+	src, err := source.ReadFile(filepath.Join(root, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil
+	}
+	fset := token.NewFileSet()
+	f, _ := parser.ParseFile(fset, rel, src, parser.SkipObjectResolution) // AST parcial ainda é útil
+	if f == nil {
+		return nil
+	}
+
+	var out []UncoveredFunc
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || !fn.Name.IsExported() {
+			continue
+		}
+		name := fn.Name.Name
+		if fn.Recv != nil && len(fn.Recv.List) > 0 {
+			recv, exported := receiverName(fn.Recv.List[0].Type)
+			if !exported {
+				continue
+			}
+			name = "(" + recv + ")." + name
+		}
+		start := fset.Position(fn.Pos()).Line
+		end := fset.Position(fn.End()).Line
+		stmts, hit := 0, 0
+		for _, b := range blocks {
+			if b.StartLine >= start && b.EndLine <= end {
+				stmts += b.NumStmts
+				if b.Count > 0 {
+					hit += b.NumStmts
+				}
+			}
+		}
+		if stmts > 0 && hit == 0 {
+			out = append(out, UncoveredFunc{
+				Package: path.Dir(importFile),
+				Func:    name,
+				File:    rel,
+				Line:    start,
+				Stmts:   stmts,
+			})
+		}
+	}
+	return out
+}
+
+// receiverName devolve "T" ou "*T" (sem parâmetros de tipo) e se T é exportado.
+func receiverName(expr ast.Expr) (string, bool) {
+	prefix := ""
+	if star, ok := expr.(*ast.StarExpr); ok {
+		prefix = "*"
+		expr = star.X
+	}
+	switch t := expr.(type) {
+	case *ast.IndexExpr:
+		expr = t.X
+	case *ast.IndexListExpr:
+		expr = t.X
+	}
+	id, ok := expr.(*ast.Ident)
+	if !ok {
+		return "", false
+	}
+	return prefix + id.Name, id.IsExported()
+}
+
+func percent(covered, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(covered) * 100.0 / float64(total)
+}
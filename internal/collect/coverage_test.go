@@ -0,0 +1,30 @@
+package collect
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveGoImportFile(t *testing.T) {
+	root := filepath.FromSlash("/repo")
+	mods := []GoModule{
+		{Path: filepath.FromSlash("/repo/go.mod"), Module: "example.com/m"},
+		{Path: filepath.FromSlash("/repo/tools/go.mod"), Module: "example.com/m/tools"},
+	}
+	tests := []struct {
+		in, want string
+	}{
+		{"example.com/m", "."},
+		{"example.com/m/pkg/a.go", "pkg/a.go"},
+		{"example.com/m/pkg", "pkg"},
+		{"example.com/m/tools", "tools"},
+		{"example.com/m/tools/gen/main.go", "tools/gen/main.go"},
+		{"example.com/mx/pkg", ""},
+		{"other.org/x", ""},
+	}
+	for _, tt := range tests {
+		if got := resolveGoImportFile(mods, root, tt.in); got != tt.want {
+			t.Errorf("resolveGoImportFile(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
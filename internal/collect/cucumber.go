@@ -0,0 +1,295 @@
+package collect
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// CucumberFeatureResult agrega os status dos passos de uma feature do cucumber.json.
+type CucumberFeatureResult struct {
+	Name      string  `json:"name"`
+	URI       string  `json:"uri,omitempty"`
+	Scenarios int     `json:"scenarios"`
+	Passed    int     `json:"passed"`
+	Failed    int     `json:"failed"`
+	Skipped   int     `json:"skipped"`
+	Pending   int     `json:"pending"`
+	Undefined int     `json:"undefined"`
+	Seconds   float64 `json:"seconds"`
+}
+
+// FailingScenario aponta um cenário com passo falho no relatório Cucumber.
+type FailingScenario struct {
+	Feature  string `json:"feature"`
+	Scenario string `json:"scenario"`
+	Step     string `json:"step"`
+	Message  string `json:"message,omitempty"`
+}
+
+// StepDef é uma definição de passo godog (`ctx.Step(`^...$`, fn)`).
+type StepDef struct {
+	Pattern string `json:"pattern"`
+	Func    string `json:"func"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+}
+
+// UndefinedStep é um passo Gherkin sem definição correspondente.
+type UndefinedStep struct {
+	Text string `json:"text"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+const maxFailingScenarios = 50
+
+// cucumberReport é o resultado de um cucumber.json (contagens + detalhes).
+type cucumberReport struct {
+	Features  []CucumberFeatureResult
+	Failing   []FailingScenario
+	Scenarios int
+	Steps     int
+}
+
+// parseCucumberJSON agrega features/cenários/passos de um arquivo cucumber.json (godog/cucumber),
+// incluindo status (`result.status`) e duração (ns) de cada passo.
+// Forma resiliente: ignora campos ausentes e erros de parse.
+func parseCucumberJSON(source *files.Source, path string) *cucumberReport {
+	data, err := source.ReadFile(path)
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+	// cucumber.json costuma ser um array de Features
+	// Estrutura mínima parcial para deserialização resiliente
+	type result struct {
+		Status   string `json:"status"`
+		Duration int64  `json:"duration"` // nanossegundos
+		Error    string `json:"error_message"`
+	}
+	type step struct {
+		Keyword string `json:"keyword"`
+		Name    string `json:"name"`
+		Result  result `json:"result"`
+	}
+	type element struct {
+		Name    string `json:"name"`
+		Keyword string `json:"keyword"`
+		Type    string `json:"type"` // "scenario" | "background"
+		Steps   []step `json:"steps"`
+	}
+	type feature struct {
+		Name     string    `json:"name"`
+		URI      string    `json:"uri"`
+		Elements []element `json:"elements"`
+	}
+	var arr []feature
+	if err := json.Unmarshal(data, &arr); err != nil {
+		// alguns geradores usam objeto raiz { "features": [...] }
+		var root struct {
+			Features []feature `json:"features"`
+		}
+		if err2 := json.Unmarshal(data, &root); err2 != nil {
+			return nil
+		}
+		arr = root.Features
+	}
+
+	background := mergedKeywords("").background
+	isScenario := func(el element) bool {
+		if el.Type != "" {
+			return strings.EqualFold(el.Type, "scenario")
+		}
+		// sem "type": só não é cenário se a keyword for de Background/Contexto
+		kw := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(el.Keyword), ":"))
+		for _, b := range background {
+			if strings.EqualFold(kw, b) {
+				return false
+			}
+		}
+		return true
+	}
+
+	rep := &cucumberReport{}
+	for _, f := range arr {
+		fr := CucumberFeatureResult{Name: f.Name, URI: f.URI}
+		for _, el := range f.Elements {
+			if isScenario(el) {
+				fr.Scenarios++
+				rep.Scenarios++
+			}
+			failedAt := -1
+			for i, st := range el.Steps {
+				rep.Steps++
+				fr.Seconds += float64(st.Result.Duration) / 1e9
+				switch strings.ToLower(st.Result.Status) {
+				case "passed":
+					fr.Passed++
+				case "failed":
+					fr.Failed++
+					if failedAt < 0 {
+						failedAt = i
+					}
+				case "skipped":
+					fr.Skipped++
+				case "pending":
+					fr.Pending++
+				case "undefined", "ambiguous":
+					fr.Undefined++
+				}
+			}
+			if failedAt >= 0 && len(rep.Failing) < maxFailingScenarios {
+				st := el.Steps[failedAt]
+				rep.Failing = append(rep.Failing, FailingScenario{
+					Feature:  f.Name,
+					Scenario: el.Name,
+					Step:     strings.TrimSpace(st.Keyword) + " " + st.Name,
+					Message:  limitLine(st.Result.Error, 200),
+				})
+			}
+		}
+		rep.Features = append(rep.Features, fr)
+	}
+	return rep
+}
+
+// stepDefMethods são os registradores de passos do godog (ScenarioContext).
+var stepDefMethods = map[string]bool{"Step": true, "Given": true, "When": true, "Then": true}
+
+// findStepDefs extrai chamadas `x.Step("^regex$", handler)` de um arquivo Go que importa godog.
+func findStepDefs(fset *token.FileSet, f *ast.File, rel string) []StepDef {
+	var out []StepDef
+	ast.Inspect(f, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) < 2 {
+			return true
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || !stepDefMethods[sel.Sel.Name] {
+			return true
+		}
+		lit, ok := call.Args[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.STRING {
+			return true
+		}
+		pattern, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return true
+		}
+		out = append(out, StepDef{
+			Pattern: pattern,
+			Func:    exprString(call.Args[1]),
+			File:    rel,
+			Line:    fset.Position(call.Pos()).Line,
+		})
+		return true
+	})
+	return out
+}
+
+// exprString devolve uma forma curta de uma expressão (ident, pkg.Ident, método...).
+func exprString(e ast.Expr) string {
+	switch x := e.(type) {
+	case *ast.Ident:
+		return x.Name
+	case *ast.SelectorExpr:
+		return exprString(x.X) + "." + x.Sel.Name
+	case *ast.StarExpr:
+		return "*" + exprString(x.X)
+	case *ast.ParenExpr:
+		return exprString(x.X)
+	case *ast.FuncLit:
+		return "func literal"
+	case *ast.CallExpr:
+		return exprString(x.Fun) + "(…)"
+	case *ast.IndexExpr:
+		return exprString(x.X)
+	case *ast.BasicLit:
+		return x.Value
+	}
+	return "?"
+}
+
+// matchStepDefinitions cruza os passos dos .feature com as definições godog e
+// preenche passos indefinidos e definições sem uso.
+func matchStepDefinitions(bdd *BDDSum, defs []StepDef) {
+	sort.Slice(defs, func(i, j int) bool {
+		if defs[i].File != defs[j].File {
+			return defs[i].File < defs[j].File
+		}
+		return defs[i].Line < defs[j].Line
+	})
+	bdd.StepDefinitions = len(defs)
+	if len(defs) == 0 || len(bdd.Specs) == 0 {
+		return
+	}
+	res := make([]*regexp.Regexp, len(defs))
+	for i, d := range defs {
+		res[i], _ = regexp.Compile(d.Pattern) // padrões inválidos ficam nil
+	}
+	used := make([]bool, len(defs))
+	match := func(text string) bool {
+		hit := false
+		for i, re := range res {
+			if re != nil && re.MatchString(text) {
+				used[i] = true
+				hit = true
+			}
+		}
+		return hit
+	}
+
+	seen := map[string]bool{}
+	check := func(st GherkinStep, file string, rows []map[string]string) {
+		texts := []string{st.Text}
+		if len(rows) > 0 && strings.Contains(st.Text, "<") {
+			texts = texts[:0]
+			for _, row := range rows {
+				t := st.Text
+				for k, v := range row {
+					t = strings.ReplaceAll(t, "<"+k+">", v)
+				}
+				texts = append(texts, t)
+			}
+		}
+		for _, t := range texts {
+			if match(t) {
+				continue
+			}
+			key := file + "\x00" + t
+			if !seen[key] {
+				seen[key] = true
+				bdd.UndefinedSteps = append(bdd.UndefinedSteps, UndefinedStep{Text: t, File: file, Line: st.Line})
+			}
+		}
+	}
+	for _, f := range bdd.Specs {
+		for _, st := range f.BackgroundSteps {
+			check(st, f.File, nil)
+		}
+		for _, sc := range f.Scenarios {
+			for _, st := range sc.Steps {
+				check(st, f.File, sc.exampleRows)
+			}
+		}
+	}
+	for i, d := range defs {
+		if !used[i] {
+			bdd.UnusedStepDefs = append(bdd.UnusedStepDefs, d)
+		}
+	}
+}
+
+// stepDefsFromSource devolve as step definitions de um arquivo que importa godog.
+func stepDefsFromSource(gs *goSource) []StepDef {
+	if !gs.importsAny("github.com/cucumber/godog", "github.com/DATA-DOG/godog") {
+		return nil
+	}
+	return findStepDefs(gs.Fset, gs.File, gs.Rel)
+}
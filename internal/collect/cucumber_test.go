@@ -0,0 +1,65 @@
+package collect
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCucumberJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want *cucumberReport
+	}{
+		{
+			name: "array de features com background e falha",
+			json: `[{
+  "name": "Login", "uri": "features/login.feature",
+  "elements": [
+    {"keyword": "Background", "steps": [
+      {"keyword": "Given ", "name": "a user", "result": {"status": "passed", "duration": 500000000}}
+    ]},
+    {"name": "ok", "type": "scenario", "steps": [
+      {"keyword": "When ", "name": "login", "result": {"status": "passed", "duration": 1000000000}},
+      {"keyword": "Then ", "name": "home", "result": {"status": "pending"}}
+    ]},
+    {"name": "bad", "keyword": "Scenario", "steps": [
+      {"keyword": "When ", "name": "wrong pass", "result": {"status": "failed", "error_message": "esperava 200\ngot 401"}},
+      {"keyword": "Then ", "name": "error", "result": {"status": "skipped"}},
+      {"keyword": "And ", "name": "x", "result": {"status": "undefined"}}
+    ]}
+  ]
+}]`,
+			want: &cucumberReport{
+				Features: []CucumberFeatureResult{{
+					Name: "Login", URI: "features/login.feature", Scenarios: 2,
+					Passed: 2, Failed: 1, Skipped: 1, Pending: 1, Undefined: 1, Seconds: 1.5,
+				}},
+				Failing:   []FailingScenario{{Feature: "Login", Scenario: "bad", Step: "When wrong pass", Message: "esperava 200"}},
+				Scenarios: 2,
+				Steps:     6,
+			},
+		},
+		{
+			name: "objeto raiz com features e keyword Contexto",
+			json: `{"features": [{"name": "F", "elements": [
+  {"keyword": "Contexto:", "steps": []},
+  {"name": "s", "keyword": "Cenário", "steps": [{"keyword": "Dado ", "name": "x", "result": {"status": "ambiguous"}}]}
+]}]}`,
+			want: &cucumberReport{
+				Features:  []CucumberFeatureResult{{Name: "F", Scenarios: 1, Undefined: 1}},
+				Scenarios: 1,
+				Steps:     1,
+			},
+		},
+		{name: "inválido", json: `{"features": 3}`},
+		{name: "vazio", json: ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseCucumberJSON(nil, writeTemp(t, "cucumber.json", tt.json)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCucumberJSON(nil, ) = %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,570 @@
+package collect
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DIGraph é o mapa de injeção de dependências (uber/fx, google/wire) de um binário.
+type DIGraph struct {
+	Binary     string       `json:"binary"` // diretório do pacote main ("" = fora de qualquer binário)
+	Modules    []DIModule   `json:"modules"`
+	Providers  []DIProvider `json:"providers"`
+	Unresolved []string     `json:"unresolved,omitempty"` // tipos pedidos sem provider conhecido
+}
+
+// DIModule é um fx.Module/fx.Options/fx.New, um wire.NewSet ou um injetor wire.Build.
+type DIModule struct {
+	Kind     string   `json:"kind"` // fx.New | fx.Module | fx.Options | wire.NewSet | wire.Build
+	Name     string   `json:"name"`
+	Where    string   `json:"where"`
+	Provides []string `json:"provides,omitempty"`
+	Invokes  []string `json:"invokes,omitempty"`
+	Includes []string `json:"includes,omitempty"` // outros módulos/sets referenciados
+	Injects  string   `json:"injects,omitempty"`  // wire: tipo devolvido pelo injetor
+}
+
+// DIProvider é um construtor (ou invoke/bind) com os tipos que fornece e
+// os que consome; Needs vem como "Tipo ← Provider" quando resolvido.
+type DIProvider struct {
+	Func     string   `json:"func"`
+	Kind     string   `json:"kind"` // provide | invoke | decorate | bind | struct | value
+	Where    string   `json:"where,omitempty"`
+	Provides []string `json:"provides,omitempty"`
+	Needs    []string `json:"needs,omitempty"`
+}
+
+// goDIFacts: containers de DI e assinaturas de funções de nível de pacote de um arquivo.
+type goDIFacts struct {
+	Dir        string
+	Containers []diContainer
+	Funcs      map[string]goFuncSig
+}
+
+type goFuncSig struct {
+	Where   string
+	Params  []string // tipos qualificados (ver qualifiedType)
+	Results []string // sem error
+}
+
+// diRef aponta para um identificador de pacote: local (Import == "") ou importado.
+type diRef struct {
+	Import, Name string
+}
+
+type diContainer struct {
+	DIModule
+	dir   string
+	key   string  // variável/função que guarda o módulo/set ("" para fx.New/wire.Build)
+	refs  []diRef // módulos/sets incluídos (ou providers, no wire)
+	provs []diProv
+}
+
+type diProv struct {
+	kind     string
+	ref      diRef // função referenciada (Name == "" para provider inline)
+	display  string
+	where    string
+	provides []string
+	needs    []string
+}
+
+// diFactsFromSource extrai fx.Module/fx.Options/fx.New e wire.NewSet/wire.Build,
+// além das assinaturas de funções (para saber o que cada construtor fornece).
+func diFactsFromSource(gs *goSource) goDIFacts {
+	facts := goDIFacts{Dir: gs.Dir, Funcs: map[string]goFuncSig{}}
+	if gs.IsTest() || gs.IsGenerated() {
+		return facts
+	}
+	for _, d := range gs.File.Decls {
+		if fd, ok := d.(*ast.FuncDecl); ok && fd.Recv == nil && fd.Type.Results != nil {
+			params, results := gs.signature(fd.Type)
+			facts.Funcs[fd.Name.Name] = goFuncSig{Where: gs.where(fd), Params: params, Results: results}
+		}
+	}
+	fxName, hasFx := gs.localName("go.uber.org/fx")
+	wireName, hasWire := gs.localName("github.com/google/wire")
+	if !hasFx && !hasWire {
+		return facts
+	}
+	p := &diParser{gs: gs, fx: fxName, wire: wireName}
+	isContainer := func(e ast.Expr) (*ast.CallExpr, string) {
+		call, ok := e.(*ast.CallExpr)
+		if !ok {
+			return nil, ""
+		}
+		switch exprString(call.Fun) {
+		case fxName + ".Module":
+			return call, "fx.Module"
+		case fxName + ".Options":
+			return call, "fx.Options"
+		case wireName + ".NewSet":
+			return call, "wire.NewSet"
+		}
+		return nil, ""
+	}
+	for _, d := range gs.File.Decls {
+		switch x := d.(type) {
+		case *ast.GenDecl:
+			for _, spec := range x.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for i, name := range vs.Names {
+					if i < len(vs.Values) {
+						if call, kind := isContainer(vs.Values[i]); call != nil {
+							facts.Containers = append(facts.Containers, p.container(kind, name.Name, name.Name, call))
+						}
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			fn := funcDeclName(x)
+			ast.Inspect(x, func(n ast.Node) bool {
+				switch y := n.(type) {
+				case *ast.ReturnStmt:
+					// func Module() fx.Option { return fx.Module(...) }
+					if len(y.Results) == 1 && x.Recv == nil {
+						if call, kind := isContainer(y.Results[0]); call != nil {
+							facts.Containers = append(facts.Containers, p.container(kind, fn, fn, call))
+							return false
+						}
+					}
+				case *ast.CallExpr:
+					switch exprString(y.Fun) {
+					case fxName + ".New":
+						if hasFx {
+							facts.Containers = append(facts.Containers, p.container("fx.New", fn, "", y))
+							return false
+						}
+					case wireName + ".Build":
+						if hasWire {
+							c := p.container("wire.Build", fn, "", y)
+							if _, results := gs.signature(x.Type); len(results) > 0 {
+								c.Injects = results[0]
+							}
+							facts.Containers = append(facts.Containers, c)
+							return false
+						}
+					}
+				}
+				return true
+			})
+		}
+	}
+	return facts
+}
+
+type diParser struct {
+	gs       *goSource
+	fx, wire string
+}
+
+func (p *diParser) container(kind, name, key string, call *ast.CallExpr) diContainer {
+	c := diContainer{DIModule: DIModule{Kind: kind, Name: name, Where: p.gs.where(call)}, dir: p.gs.Dir, key: key}
+	args := call.Args
+	if kind == "fx.Module" && len(args) > 0 {
+		if s, err := strconv.Unquote(exprString(args[0])); err == nil {
+			c.Name = s
+		}
+		args = args[1:]
+	}
+	if strings.HasPrefix(kind, "wire.") {
+		p.wireArgs(&c, args)
+	} else {
+		p.fxOptions(&c, args)
+	}
+	return c
+}
+
+// fxOptions percorre as opções de fx.New/fx.Module/fx.Options.
+func (p *diParser) fxOptions(c *diContainer, args []ast.Expr) {
+	for _, a := range args {
+		call, isCall := a.(*ast.CallExpr)
+		if !isCall {
+			if ref, ok := p.ref(a); ok {
+				c.refs = append(c.refs, ref)
+			}
+			continue
+		}
+		switch fn := exprString(call.Fun); fn {
+		case p.fx + ".Provide":
+			p.fxProviders(c, call.Args, "provide")
+		case p.fx + ".Invoke":
+			p.fxProviders(c, call.Args, "invoke")
+		case p.fx + ".Decorate":
+			p.fxProviders(c, call.Args, "decorate")
+		case p.fx + ".Supply":
+			for _, s := range call.Args {
+				pv := diProv{kind: "value", display: "fx.Supply", where: p.gs.where(s)}
+				if cl := compositeLit(s); cl != nil {
+					t := p.gs.qualifiedType(cl.Type)
+					if _, ok := s.(*ast.UnaryExpr); ok {
+						t = "*" + t
+					}
+					pv.provides = []string{t}
+				}
+				c.provs = append(c.provs, pv)
+			}
+		case p.fx + ".Module":
+			if len(call.Args) > 0 {
+				c.Includes = append(c.Includes, "fx.Module("+exprString(call.Args[0])+")")
+				p.fxOptions(c, call.Args[1:])
+			}
+		case p.fx + ".Options":
+			p.fxOptions(c, call.Args)
+		default:
+			// Module() devolvendo fx.Option
+			if len(call.Args) == 0 {
+				if ref, ok := p.ref(call.Fun); ok {
+					c.refs = append(c.refs, ref)
+				}
+			}
+		}
+	}
+}
+
+func (p *diParser) fxProviders(c *diContainer, args []ast.Expr, kind string) {
+	for _, a := range args {
+		if call, ok := a.(*ast.CallExpr); ok && exprString(call.Fun) == p.fx+".Annotate" && len(call.Args) > 0 {
+			a = call.Args[0]
+		}
+		if cl := compositeLit(a); cl != nil && exprString(cl.Type) == p.fx+".Annotated" {
+			if t := compositeField(cl, "Target"); t != nil {
+				a = t
+			}
+		}
+		c.provs = append(c.provs, p.provider(a, kind))
+	}
+}
+
+// wireArgs percorre wire.NewSet/wire.Build.
+func (p *diParser) wireArgs(c *diContainer, args []ast.Expr) {
+	newArg := func(e ast.Expr) ast.Expr { // new(T) -> T
+		if call, ok := e.(*ast.CallExpr); ok && exprString(call.Fun) == "new" && len(call.Args) == 1 {
+			return call.Args[0]
+		}
+		return nil
+	}
+	for _, a := range args {
+		call, isCall := a.(*ast.CallExpr)
+		if !isCall {
+			if ref, ok := p.ref(a); ok {
+				c.refs = append(c.refs, ref)
+			}
+			continue
+		}
+		where := p.gs.where(call)
+		switch fn := exprString(call.Fun); fn {
+		case p.wire + ".NewSet":
+			p.wireArgs(c, call.Args)
+		case p.wire + ".Bind":
+			if len(call.Args) == 2 && newArg(call.Args[0]) != nil && newArg(call.Args[1]) != nil {
+				iface, impl := p.gs.qualifiedType(newArg(call.Args[0])), p.gs.qualifiedType(newArg(call.Args[1]))
+				c.provs = append(c.provs, diProv{kind: "bind", display: "wire.Bind", where: where,
+					provides: []string{iface}, needs: []string{impl}})
+			}
+		case p.wire + ".Struct":
+			if len(call.Args) > 0 && newArg(call.Args[0]) != nil {
+				t := p.gs.qualifiedType(newArg(call.Args[0]))
+				c.provs = append(c.provs, diProv{kind: "struct", display: "wire.Struct", where: where,
+					provides: []string{t, "*" + t}})
+			}
+		case p.wire + ".InterfaceValue":
+			if len(call.Args) == 2 && newArg(call.Args[0]) != nil {
+				c.provs = append(c.provs, diProv{kind: "value", display: "wire.InterfaceValue", where: where, provides: []string{p.gs.qualifiedType(newArg(call.Args[0]))}})
+			}
+		case p.wire + ".Value":
+			if len(call.Args) == 1 {
+				pv := diProv{kind: "value", display: "wire.Value", where: where}
+				if cl := compositeLit(call.Args[0]); cl != nil {
+					pv.provides = []string{p.gs.qualifiedType(cl.Type)}
+				}
+				c.provs = append(c.provs, pv)
+			}
+		case p.wire + ".FieldsOf":
+			if len(call.Args) > 0 && newArg(call.Args[0]) != nil {
+				c.provs = append(c.provs, diProv{kind: "struct", display: "wire.FieldsOf", where: where,
+					needs: []string{p.gs.qualifiedType(newArg(call.Args[0]))}})
+			}
+		}
+	}
+}
+
+// provider: referência a função (resolvida depois) ou func literal inline.
+func (p *diParser) provider(e ast.Expr, kind string) diProv {
+	pv := diProv{kind: kind, display: exprString(e), where: p.gs.where(e)}
+	if fl, ok := e.(*ast.FuncLit); ok {
+		pv.display = "func literal"
+		pv.needs, pv.provides = p.gs.signature(fl.Type)
+		return pv
+	}
+	if ref, ok := p.ref(e); ok {
+		pv.ref = ref
+		if ref.Import == "" && p.gs.Package() != "main" {
+			pv.display = p.gs.Package() + "." + ref.Name
+		}
+	}
+	return pv
+}
+
+// ref resolve Nome / pkg.Nome para um identificador de pacote.
+func (p *diParser) ref(e ast.Expr) (diRef, bool) {
+	switch x := e.(type) {
+	case *ast.Ident:
+		return diRef{Name: x.Name}, true
+	case *ast.SelectorExpr:
+		if id, ok := x.X.(*ast.Ident); ok {
+			if imp, ok := p.gs.importPathOf(id.Name); ok {
+				return diRef{Import: imp, Name: x.Sel.Name}, true
+			}
+		}
+	}
+	return diRef{}, false
+}
+
+// signature devolve os tipos (qualificados) dos parâmetros e resultados, sem error.
+func (gs *goSource) signature(ft *ast.FuncType) (params, results []string) {
+	fields := func(fl *ast.FieldList, skipError bool) []string {
+		var out []string
+		if fl == nil {
+			return nil
+		}
+		for _, f := range fl.List {
+			t := gs.qualifiedType(f.Type)
+			if skipError && t == "error" {
+				continue
+			}
+			n := len(f.Names)
+			if n == 0 {
+				n = 1
+			}
+			for i := 0; i < n; i++ {
+				out = append(out, t)
+			}
+		}
+		return out
+	}
+	return fields(ft.Params, false), fields(ft.Results, true)
+}
+
+// localName devolve o nome local de um import, se o arquivo o importa.
+func (gs *goSource) localName(importPath string) (string, bool) {
+	name, ok := gs.Imports[importPath]
+	return name, ok
+}
+
+func (gs *goSource) where(n ast.Node) string {
+	return fmt.Sprintf("%s:%d", gs.Rel, gs.Line(n.Pos()))
+}
+
+// whereLess ordena "arquivo:linha" por arquivo e depois pelo número da linha.
+func whereLess(a, b string) bool {
+	fa, la, _ := strings.Cut(a, ":")
+	fb, lb, _ := strings.Cut(b, ":")
+	if fa != fb {
+		return fa < fb
+	}
+	return atoiSafe(la) < atoiSafe(lb)
+}
+
+// buildDIGraphs resolve referências entre pacotes, monta o grafo
+// tipo -> construtor e agrupa por binário: os containers alcançáveis a partir
+// de fx.New/wire.Build nos pacotes de cada main.
+func buildDIGraphs(facts []goDIFacts, ix *goPackageIndex) []DIGraph {
+	var containers []*diContainer
+	byKey := map[string]*diContainer{}
+	funcs := map[string]goFuncSig{}
+	for i := range facts {
+		f := &facts[i]
+		for name, sig := range f.Funcs {
+			funcs[f.Dir+"."+name] = sig
+		}
+		for j := range f.Containers {
+			c := &f.Containers[j]
+			containers = append(containers, c)
+		}
+	}
+	if len(containers) == 0 {
+		return nil
+	}
+	// facts chegam na ordem em que as goroutines terminaram
+	sort.SliceStable(containers, func(i, j int) bool { return whereLess(containers[i].Where, containers[j].Where) })
+	for _, c := range containers {
+		if c.key != "" {
+			byKey[c.dir+"."+c.key] = c
+		}
+	}
+	refKey := func(dir string, r diRef) string {
+		if r.Import != "" {
+			dir = ix.importDir(r.Import)
+			if dir == "" {
+				return ""
+			}
+		}
+		return dir + "." + r.Name
+	}
+	// includes e providers com assinatura resolvida; tipos em forma canônica
+	// (para casar provides x needs) e curta (para exibição)
+	type provider struct {
+		DIProvider
+		provides, needs, needsShort []string
+	}
+	includes := map[*diContainer][]*diContainer{}
+	providers := map[*diContainer][]provider{}
+	for _, c := range containers {
+		for _, ref := range c.refs {
+			if inc := byKey[refKey(c.dir, ref)]; inc != nil && inc != c {
+				includes[c] = append(includes[c], inc)
+				c.Includes = append(c.Includes, diRefName(ref, inc))
+			} else if strings.HasPrefix(c.Kind, "wire.") {
+				// no wire, identificadores soltos são sets ou construtores
+				display := diRefName(ref, nil)
+				if pkg := ix.pkgs[c.dir]; ref.Import == "" && pkg != nil && pkg.Name != "main" {
+					display = pkg.Name + "." + ref.Name
+				}
+				c.provs = append(c.provs, diProv{kind: "provide", ref: ref, display: display})
+			}
+		}
+		for _, pv := range c.provs {
+			pr := provider{DIProvider: DIProvider{Func: pv.display, Kind: pv.kind, Where: pv.where}}
+			provides, needs := pv.provides, pv.needs
+			if pv.ref.Name != "" {
+				if sig, ok := funcs[refKey(c.dir, pv.ref)]; ok {
+					provides, needs, pr.Where = sig.Results, sig.Params, sig.Where
+				}
+			}
+			if pv.kind == "invoke" {
+				provides = nil
+				c.Invokes = append(c.Invokes, pv.display)
+			} else {
+				c.Provides = append(c.Provides, pv.display)
+			}
+			for _, t := range provides {
+				canon, short := ix.canonicalType(t)
+				pr.provides, pr.Provides = append(pr.provides, canon), append(pr.Provides, short)
+			}
+			for _, t := range needs {
+				canon, short := ix.canonicalType(t)
+				pr.needs, pr.needsShort = append(pr.needs, canon), append(pr.needsShort, short)
+			}
+			providers[c] = append(providers[c], pr)
+		}
+	}
+
+	closure := func(roots []*diContainer) []*diContainer {
+		seen := map[*diContainer]bool{}
+		var out []*diContainer
+		var visit func(c *diContainer)
+		visit = func(c *diContainer) {
+			if seen[c] {
+				return
+			}
+			seen[c] = true
+			out = append(out, c)
+			for _, inc := range includes[c] {
+				visit(inc)
+			}
+		}
+		for _, r := range roots {
+			visit(r)
+		}
+		return out
+	}
+	graph := func(binary string, cs []*diContainer) DIGraph {
+		g := DIGraph{Binary: binary}
+		providerOf := map[string]string{}
+		for _, c := range cs {
+			for _, pr := range providers[c] {
+				for _, t := range pr.provides {
+					if _, ok := providerOf[t]; !ok {
+						providerOf[t] = pr.Func
+					}
+				}
+			}
+		}
+		unresolved := map[string]bool{}
+		seen := map[string]bool{} // o mesmo construtor listado em vários injetores
+		for _, c := range cs {
+			m := c.DIModule
+			if m.Injects != "" {
+				_, m.Injects = ix.canonicalType(m.Injects)
+			}
+			g.Modules = append(g.Modules, m)
+			for _, pr := range providers[c] {
+				k := pr.Func + "\x00" + pr.Where
+				if seen[k] {
+					continue
+				}
+				seen[k] = true
+				dp := pr.DIProvider
+				for i, t := range pr.needs {
+					if from, ok := providerOf[t]; ok {
+						dp.Needs = append(dp.Needs, pr.needsShort[i]+" ← "+from)
+					} else {
+						dp.Needs = append(dp.Needs, pr.needsShort[i])
+						if !strings.HasPrefix(t, "go.uber.org/fx.") { // fx.Lifecycle, fx.Shutdowner...
+							unresolved[pr.needsShort[i]] = true
+						}
+					}
+				}
+				g.Providers = append(g.Providers, dp)
+			}
+		}
+		for t := range unresolved {
+			g.Unresolved = append(g.Unresolved, t)
+		}
+		sort.Strings(g.Unresolved)
+		return g
+	}
+
+	var out []DIGraph
+	reached := map[*diContainer]bool{}
+	for _, dir := range ix.mainDirs() {
+		dirs := map[string]bool{}
+		for _, d := range ix.reachable(dir) {
+			dirs[d] = true
+		}
+		var roots []*diContainer
+		for _, c := range containers {
+			if (c.Kind == "fx.New" || c.Kind == "wire.Build") && dirs[c.dir] {
+				roots = append(roots, c)
+			}
+		}
+		if len(roots) == 0 {
+			continue
+		}
+		cs := closure(roots)
+		for _, c := range cs {
+			reached[c] = true
+		}
+		out = append(out, graph(dir, cs))
+	}
+	var orphan []*diContainer
+	for _, c := range containers {
+		if !reached[c] {
+			orphan = append(orphan, c)
+		}
+	}
+	if len(orphan) > 0 {
+		sort.Slice(orphan, func(i, j int) bool { return whereLess(orphan[i].Where, orphan[j].Where) })
+		out = append(out, graph("", orphan))
+	}
+	return out
+}
+
+// diRefName é o nome de exibição de uma referência (pkg.Nome ou Nome).
+func diRefName(r diRef, c *diContainer) string {
+	name := r.Name
+	if r.Import != "" {
+		name = importBaseName(r.Import) + "." + r.Name
+	}
+	if c != nil && c.Kind == "fx.Module" && c.Name != c.key {
+		name += " (" + strconv.Quote(c.Name) + ")"
+	}
+	return name
+}
@@ -0,0 +1,426 @@
+package collect
+
+import (
+	"fmt"
+	"go/ast"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// AsyncAPISpec descreve um documento AsyncAPI (2.x ou 3.x).
+type AsyncAPISpec struct {
+	File     string         `json:"file"`
+	Format   string         `json:"format"` // "asyncapi 2.6.0"
+	Title    string         `json:"title,omitempty"`
+	Version  string         `json:"version,omitempty"`
+	Servers  []string       `json:"servers,omitempty"` // "url (protocol)"
+	Channels []AsyncChannel `json:"channels,omitempty"`
+	RefFiles []string       `json:"ref_files,omitempty"`
+	Unparsed string         `json:"unparsed,omitempty"` // ver APISpec.Unparsed
+}
+
+// AsyncChannel é um canal/subject do spec, com mensagens e direção do ponto
+// de vista da aplicação descrita.
+type AsyncChannel struct {
+	Address  string   `json:"address"`
+	Messages []string `json:"messages,omitempty"`
+	Payloads []string `json:"payloads,omitempty"`
+	Send     bool     `json:"send,omitempty"`    // a aplicação publica
+	Receive  bool     `json:"receive,omitempty"` // a aplicação consome
+}
+
+// EventEndpoint é um publish/subscribe encontrado estaticamente no código Go.
+type EventEndpoint struct {
+	Subject   string `json:"subject"`
+	Role      string `json:"role"`      // "publish" | "subscribe"
+	Transport string `json:"transport"` // "nats" | "kafka" | "amqp"
+	Func      string `json:"func,omitempty"`
+	Where     string `json:"where"` // arquivo:linha
+	Queue     string `json:"queue,omitempty"`
+}
+
+// EventFlow agrega, por subject/tópico, quem publica e quem consome.
+type EventFlow struct {
+	Subject     string   `json:"subject"`
+	Transports  []string `json:"transports"`
+	Publishers  []string `json:"publishers,omitempty"`
+	Subscribers []string `json:"subscribers,omitempty"`
+}
+
+// goEventFacts é o que um arquivo .go contribui (subjects ainda com ${...}).
+type goEventFacts struct {
+	Dir  string
+	Uses []EventEndpoint
+}
+
+var asyncAPIYAMLRe = regexp.MustCompile(`(?m)^asyncapi\s*:`)
+
+// parseAsyncAPI lê canais, mensagens e payloads de um spec AsyncAPI.
+// 2.x: "subscribe" = a aplicação envia, "publish" = a aplicação recebe.
+// 3.x: channels[id].address + operations{action: send|receive, channel: $ref}.
+func parseAsyncAPI(source *files.Source, root, rel string) (*AsyncAPISpec, error) {
+	l := &specLoader{source: source, root: root, docs: map[string]any{}}
+	raw, err := l.loadDoc(rel)
+	if err != nil && apiSpecKind(source, filepath.Join(root, rel), strings.ToLower(rel)) == "asyncapi" {
+		return &AsyncAPISpec{File: rel, Format: "asyncapi", Unparsed: err.Error()}, nil
+	}
+	doc, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("asyncapi: %s não é um documento", rel)
+	}
+	v := settingString(doc["asyncapi"])
+	if v == "" {
+		return nil, fmt.Errorf("asyncapi: %s sem chave asyncapi", rel)
+	}
+	spec := &AsyncAPISpec{
+		File:    rel,
+		Format:  "asyncapi " + v,
+		Title:   settingString(getPath(doc, "info.title")),
+		Version: settingString(getPath(doc, "info.version")),
+	}
+	servers, _ := doc["servers"].(map[string]any)
+	for _, name := range sortedKeys(servers) {
+		s, _ := l.deref(servers[name], rel)
+		url := settingString(firstNonNil(getPath(s, "url"), getPath(s, "host")))
+		if p := settingString(getPath(s, "pathname")); p != "" {
+			url += p
+		}
+		if proto := settingString(getPath(s, "protocol")); proto != "" {
+			url += " (" + proto + ")"
+		}
+		spec.Servers = append(spec.Servers, strings.TrimSpace(url))
+	}
+
+	channels, _ := doc["channels"].(map[string]any)
+	byID := map[string]int{}
+	for _, id := range sortedKeys(channels) {
+		node, f := l.deref(channels[id], rel)
+		ch := AsyncChannel{Address: id}
+		if addr := settingString(getPath(node, "address")); addr != "" {
+			ch.Address = addr
+		}
+		if strings.HasPrefix(v, "2") {
+			for _, op := range []string{"subscribe", "publish"} {
+				opNode, of := l.deref(getPath(node, op), f)
+				if opNode == nil {
+					continue
+				}
+				if op == "subscribe" {
+					ch.Send = true
+				} else {
+					ch.Receive = true
+				}
+				l.addMessages(&ch, getPath(opNode, "message"), of, "")
+			}
+		} else {
+			msgs, _ := getPath(node, "messages").(map[string]any)
+			for _, name := range sortedKeys(msgs) {
+				l.addMessages(&ch, msgs[name], f, name)
+			}
+		}
+		spec.Channels = append(spec.Channels, ch)
+		byID[id] = len(spec.Channels) - 1
+	}
+
+	// 3.x: a direção vem das operations
+	ops, _ := doc["operations"].(map[string]any)
+	for _, id := range sortedKeys(ops) {
+		op, _ := l.deref(ops[id], rel)
+		ref := settingString(getPath(op, "channel.$ref"))
+		i, ok := byID[strings.NewReplacer("~1", "/", "~0", "~").Replace(path.Base(ref))]
+		if !ok {
+			continue
+		}
+		ch := &spec.Channels[i]
+		switch settingString(getPath(op, "action")) {
+		case "send":
+			ch.Send = true
+		case "receive":
+			ch.Receive = true
+		}
+	}
+
+	for f := range l.docs {
+		if f != rel && l.docs[f] != nil {
+			spec.RefFiles = append(spec.RefFiles, f)
+		}
+	}
+	sort.Strings(spec.RefFiles)
+	return spec, nil
+}
+
+// addMessages registra nome e payload de uma mensagem (ou oneOf de mensagens).
+// name é a chave em channels.messages (3.x); vazio no 2.x.
+func (l *specLoader) addMessages(ch *AsyncChannel, node any, file, name string) {
+	if ref := settingString(getPath(node, "$ref")); ref != "" && name == "" {
+		name = ref[strings.LastIndex(ref, "/")+1:]
+	}
+	msg, f := l.deref(node, file)
+	if alts, ok := getPath(msg, "oneOf").([]any); ok {
+		for _, a := range alts {
+			l.addMessages(ch, a, f, "")
+		}
+		return
+	}
+	if msg == nil {
+		return
+	}
+	if n := settingString(firstNonNil(getPath(msg, "name"), getPath(msg, "title"))); n != "" {
+		name = n
+	}
+	if name != "" && !containsString(ch.Messages, name) {
+		ch.Messages = append(ch.Messages, name)
+	}
+	if p := l.schemaName(getPath(msg, "payload"), f); p != "" && !containsString(ch.Payloads, p) {
+		ch.Payloads = append(ch.Payloads, p)
+	}
+}
+
+// bibliotecas de mensageria reconhecidas no código Go
+var (
+	natsImports  = []string{"github.com/nats-io/"}
+	kafkaImports = []string{"github.com/segmentio/kafka-go", "github.com/confluentinc/confluent-kafka-go/",
+		"github.com/IBM/sarama", "github.com/Shopify/sarama", "github.com/twmb/franz-go/"}
+	amqpImports = []string{"github.com/rabbitmq/amqp091-go", "github.com/streadway/amqp"}
+)
+
+// eventFactsFromSource procura Publish/Subscribe (nats.go), writers/readers
+// Kafka (kafka-go, confluent, sarama, franz-go) e publish/consume AMQP.
+// Subjects são avaliados estaticamente; referências não resolvidas no arquivo
+// ficam como ${Nome} para a consolidação.
+func eventFactsFromSource(gs *goSource, pkgConsts map[string]string) goEventFacts {
+	facts := goEventFacts{Dir: gs.Dir}
+	if gs.IsTest() {
+		return facts
+	}
+	hasNATS, hasKafka, hasAMQP := gs.importsAny(natsImports...), gs.importsAny(kafkaImports...), gs.importsAny(amqpImports...)
+	if !hasNATS && !hasKafka && !hasAMQP {
+		return facts
+	}
+	kafkaNames := map[string]bool{}
+	for p, name := range gs.Imports {
+		for _, k := range kafkaImports {
+			if p == k || (strings.HasSuffix(k, "/") && strings.HasPrefix(p, k)) {
+				kafkaNames[name] = true
+			}
+		}
+	}
+
+	for _, d := range gs.File.Decls {
+		fn, consts := "", pkgConsts
+		if fd, ok := d.(*ast.FuncDecl); ok {
+			fn = funcDeclName(fd)
+			consts = gs.localStringConsts(fd.Body, pkgConsts)
+		}
+		eval := func(e ast.Expr) string { return gs.evalString(e, consts) }
+		add := func(n ast.Node, role, transport, subject, queue string) {
+			facts.Uses = append(facts.Uses, EventEndpoint{
+				Subject:   subject,
+				Role:      role,
+				Transport: transport,
+				Func:      fn,
+				Where:     fmt.Sprintf("%s:%d", gs.Rel, gs.Line(n.Pos())),
+				Queue:     queue,
+			})
+		}
+		ast.Inspect(d, func(n ast.Node) bool {
+			switch x := n.(type) {
+			case *ast.CallExpr:
+				sel, ok := x.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				args, method := x.Args, sel.Sel.Name
+				if len(args) > 0 && isContextArg(args[0]) {
+					args = args[1:]
+				}
+				switch {
+				case hasAMQP && (method == "Publish" || method == "PublishWithContext") && len(args) == 5:
+					add(x, "publish", "amqp", amqpSubject(eval(args[0]), eval(args[1])), "")
+				case hasAMQP && (method == "Consume" || method == "ConsumeWithContext") && len(args) == 7:
+					add(x, "subscribe", "amqp", eval(args[0]), "")
+				case hasAMQP && method == "QueueBind" && len(args) == 5:
+					add(x, "subscribe", "amqp", amqpSubject(eval(args[2]), eval(args[1])), eval(args[0]))
+				case hasNATS && len(args) >= 1 && natsPublish[method]:
+					if cl := compositeLit(args[0]); cl != nil {
+						if v := compositeField(cl, "Subject"); v != nil {
+							add(x, "publish", "nats", eval(v), "")
+						}
+					} else {
+						add(x, "publish", "nats", eval(args[0]), "")
+					}
+				case hasNATS && len(args) >= 1 && natsSubscribe[method]:
+					add(x, "subscribe", "nats", eval(args[0]), "")
+				case hasNATS && len(args) >= 2 && natsQueueSubscribe[method]:
+					add(x, "subscribe", "nats", eval(args[0]), eval(args[1]))
+				case hasKafka && method == "ConsumePartition" && len(args) == 3:
+					add(x, "subscribe", "kafka", eval(args[0]), "") // sarama
+				case hasKafka && (method == "Consume" && len(args) == 2 || method == "SubscribeTopics" && len(args) == 2):
+					for _, t := range stringSliceElems(args[0]) { // sarama ConsumerGroup / confluent
+						add(x, "subscribe", "kafka", eval(t), "")
+					}
+				case hasKafka && !hasNATS && method == "Subscribe" && len(args) == 2:
+					add(x, "subscribe", "kafka", eval(args[0]), "") // confluent
+				case hasKafka && kafkaNames[exprString(sel.X)] && method == "ConsumeTopics":
+					for _, t := range args { // franz-go kgo.ConsumeTopics(...)
+						add(x, "subscribe", "kafka", eval(t), "")
+					}
+				case hasKafka && kafkaNames[exprString(sel.X)] && method == "DefaultProduceTopic" && len(args) == 1:
+					add(x, "publish", "kafka", eval(args[0]), "")
+				}
+			case *ast.CompositeLit:
+				if !hasKafka {
+					return true
+				}
+				sel, ok := x.Type.(*ast.SelectorExpr)
+				if !ok || !kafkaNames[exprString(sel.X)] {
+					return true
+				}
+				switch sel.Sel.Name {
+				case "Writer", "WriterConfig", "Message", "ProducerMessage", "Record", "TopicPartition":
+					if v := compositeField(x, "Topic"); v != nil {
+						add(x, "publish", "kafka", eval(v), "")
+					}
+				case "ReaderConfig":
+					queue := ""
+					if g := compositeField(x, "GroupID"); g != nil {
+						queue = eval(g)
+					}
+					if v := compositeField(x, "Topic"); v != nil {
+						add(x, "subscribe", "kafka", eval(v), queue)
+					}
+					for _, t := range stringSliceElems(compositeField(x, "GroupTopics")) {
+						add(x, "subscribe", "kafka", eval(t), queue)
+					}
+				}
+			}
+			return true
+		})
+	}
+	return facts
+}
+
+var (
+	natsPublish = map[string]bool{"Publish": true, "PublishMsg": true, "PublishAsync": true,
+		"PublishMsgAsync": true, "Request": true, "RequestMsg": true}
+	natsSubscribe = map[string]bool{"Subscribe": true, "SubscribeSync": true, "ChanSubscribe": true,
+		"PullSubscribe": true}
+	natsQueueSubscribe = map[string]bool{"QueueSubscribe": true, "QueueSubscribeSync": true,
+		"ChanQueueSubscribe": true, "QueueSubscribeSyncWithChan": true}
+)
+
+// isContextArg reconhece um context.Context como primeiro argumento
+// (ctx, context.Background(), r.Context()...).
+func isContextArg(e ast.Expr) bool {
+	s := exprString(e)
+	return strings.Contains(strings.ToLower(s), "ctx") || strings.HasPrefix(s, "context.") ||
+		strings.HasSuffix(s, "Context()")
+}
+
+// amqpSubject: exchange default ("") roteia direto para a fila de nome key.
+func amqpSubject(exchange, key string) string {
+	if exchange == "" {
+		return key
+	}
+	return exchange + ":" + key
+}
+
+// compositeLit desembrulha &T{...}.
+func compositeLit(e ast.Expr) *ast.CompositeLit {
+	if u, ok := e.(*ast.UnaryExpr); ok {
+		e = u.X
+	}
+	cl, _ := e.(*ast.CompositeLit)
+	return cl
+}
+
+// compositeField devolve o valor de um campo nomeado de um literal composto.
+func compositeField(cl *ast.CompositeLit, name string) ast.Expr {
+	for _, el := range cl.Elts {
+		if kv, ok := el.(*ast.KeyValueExpr); ok {
+			if id, ok := kv.Key.(*ast.Ident); ok && id.Name == name {
+				return kv.Value
+			}
+		}
+	}
+	return nil
+}
+
+// stringSliceElems devolve os elementos de []string{...}; outra expressão
+// é tratada como um único valor.
+func stringSliceElems(e ast.Expr) []ast.Expr {
+	if e == nil {
+		return nil
+	}
+	if cl, ok := e.(*ast.CompositeLit); ok {
+		if _, ok := cl.Type.(*ast.ArrayType); ok {
+			return cl.Elts
+		}
+	}
+	return []ast.Expr{e}
+}
+
+// buildEventFlows resolve constantes entre arquivos/pacotes e agrupa os usos
+// do código e os canais AsyncAPI por subject.
+func buildEventFlows(facts []goEventFacts, specs []AsyncAPISpec, ix *goPackageIndex) []EventFlow {
+	byKey := map[string]*EventFlow{}
+	get := func(subject, transport string) *EventFlow {
+		ef := byKey[subject]
+		if ef == nil {
+			ef = &EventFlow{Subject: subject}
+			byKey[subject] = ef
+		}
+		if transport != "" && !containsString(ef.Transports, transport) {
+			ef.Transports = append(ef.Transports, transport)
+		}
+		return ef
+	}
+	for _, f := range facts {
+		for _, u := range f.Uses {
+			subject := ix.resolve(u.Subject, f.Dir)
+			who := u.Where
+			if u.Func != "" {
+				who = u.Func + " (" + u.Where + ")"
+			}
+			if u.Queue != "" {
+				who += " [queue " + ix.resolve(u.Queue, f.Dir) + "]"
+			}
+			ef := get(subject, u.Transport)
+			if u.Role == "publish" {
+				ef.Publishers = append(ef.Publishers, who)
+			} else {
+				ef.Subscribers = append(ef.Subscribers, who)
+			}
+		}
+	}
+	for _, s := range specs {
+		who := s.File
+		if s.Title != "" {
+			who = s.Title + " (" + s.File + ")"
+		}
+		for _, ch := range s.Channels {
+			ef := get(ch.Address, "")
+			if ch.Send {
+				ef.Publishers = append(ef.Publishers, who)
+			}
+			if ch.Receive {
+				ef.Subscribers = append(ef.Subscribers, who)
+			}
+		}
+	}
+
+	out := make([]EventFlow, 0, len(byKey))
+	for _, ef := range byKey {
+		sort.Strings(ef.Transports)
+		ef.Publishers = dedupeSorted(ef.Publishers)
+		ef.Subscribers = dedupeSorted(ef.Subscribers)
+		out = append(out, *ef)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Subject < out[j].Subject })
+	return out
+}
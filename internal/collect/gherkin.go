@@ -0,0 +1,350 @@
+package collect
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// GherkinFeature descreve um arquivo .feature (Feature + cenários).
+type GherkinFeature struct {
+	File            string            `json:"file"`
+	Language        string            `json:"language"`   // "# language: pt" (default "en")
+	Capability      string            `json:"capability"` // diretório sob features/ (ou nome do arquivo)
+	Name            string            `json:"name"`
+	Description     string            `json:"description,omitempty"`
+	Tags            []string          `json:"tags,omitempty"`
+	HasBackground   bool              `json:"has_background"`
+	Scenarios       []GherkinScenario `json:"scenarios"`
+	BackgroundSteps []GherkinStep     `json:"-"`
+}
+
+// GherkinScenario é um Scenario/Example ou Scenario Outline.
+type GherkinScenario struct {
+	Name     string        `json:"name"`
+	Kind     string        `json:"kind"` // scenario | outline
+	Line     int           `json:"line"`
+	Rule     string        `json:"rule,omitempty"`
+	Tags     []string      `json:"tags,omitempty"`     // tags do próprio cenário (sem herança)
+	Examples int           `json:"examples,omitempty"` // linhas de dados somadas das tabelas Examples
+	Steps    []GherkinStep `json:"-"`
+
+	exampleRows []map[string]string // usado para instanciar passos de outlines
+	ruleTags    []string
+}
+
+// GherkinStep é um passo (Given/When/Then...) com o texto sem a palavra-chave.
+type GherkinStep struct {
+	Keyword string
+	Text    string
+	Line    int
+}
+
+// EffectiveTags devolve tags herdadas (feature + rule) seguidas das do cenário.
+func (f *GherkinFeature) EffectiveTags(sc GherkinScenario) []string {
+	out := append([]string(nil), f.Tags...)
+	out = append(out, sc.ruleTags...)
+	return append(out, sc.Tags...)
+}
+
+// gherkinKeywords agrupa as palavras-chave de um idioma (subset do gherkin-languages.json).
+type gherkinKeywords struct {
+	feature, background, scenario, outline, examples, rule, steps []string
+}
+
+var gherkinLanguages = map[string]gherkinKeywords{
+	"en": {
+		feature:    []string{"Feature", "Business Need", "Ability"},
+		background: []string{"Background"},
+		scenario:   []string{"Scenario", "Example"},
+		outline:    []string{"Scenario Outline", "Scenario Template"},
+		examples:   []string{"Examples", "Scenarios"},
+		rule:       []string{"Rule"},
+		steps:      []string{"Given", "When", "Then", "And", "But", "*"},
+	},
+	"pt": {
+		feature:    []string{"Funcionalidade", "Característica", "Caracteristica"},
+		background: []string{"Contexto", "Cenário de Fundo", "Cenario de Fundo", "Fundo"},
+		scenario:   []string{"Cenário", "Cenario", "Exemplo"},
+		outline:    []string{"Esquema do Cenário", "Esquema do Cenario", "Delineação do Cenário", "Delineacao do Cenario"},
+		examples:   []string{"Exemplos", "Cenários", "Cenarios"},
+		rule:       []string{"Regra"},
+		steps:      []string{"Dado", "Dada", "Dados", "Dadas", "Quando", "Então", "Entao", "E", "Mas", "*"},
+	},
+	"es": {
+		feature:    []string{"Característica", "Necesidad del negocio", "Requisito"},
+		background: []string{"Antecedentes"},
+		scenario:   []string{"Escenario", "Ejemplo"},
+		outline:    []string{"Esquema del escenario"},
+		examples:   []string{"Ejemplos"},
+		rule:       []string{"Regla", "Regla de negocio"},
+		steps:      []string{"Dado", "Dada", "Dados", "Dadas", "Cuando", "Entonces", "Y", "E", "Pero", "*"},
+	},
+	"fr": {
+		feature:    []string{"Fonctionnalité"},
+		background: []string{"Contexte"},
+		scenario:   []string{"Scénario", "Exemple"},
+		outline:    []string{"Plan du scénario", "Plan du Scénario"},
+		examples:   []string{"Exemples"},
+		rule:       []string{"Règle"},
+		steps:      []string{"Soit", "Sachant que", "Etant donné", "Étant donné", "Quand", "Lorsque", "Alors", "Et", "Mais", "*"},
+	},
+	"de": {
+		feature:    []string{"Funktionalität", "Funktion"},
+		background: []string{"Grundlage", "Hintergrund", "Voraussetzungen"},
+		scenario:   []string{"Szenario", "Beispiel"},
+		outline:    []string{"Szenariogrundriss", "Szenarien"},
+		examples:   []string{"Beispiele"},
+		rule:       []string{"Regel", "Rule"},
+		steps:      []string{"Angenommen", "Gegeben sei", "Gegeben seien", "Wenn", "Dann", "Und", "Aber", "*"},
+	},
+}
+
+// gherkinBlockKind classifica uma linha "Keyword: título" no idioma ativo.
+func gherkinBlockKind(kw gherkinKeywords, line string) (kind, title string) {
+	colon := strings.Index(line, ":")
+	if colon <= 0 {
+		return "", ""
+	}
+	head := strings.TrimSpace(line[:colon])
+	title = strings.TrimSpace(line[colon+1:])
+	// outline antes de scenario: "Scenario Outline" começa com "Scenario"
+	for _, c := range []struct {
+		kind string
+		list []string
+	}{
+		{"outline", kw.outline}, {"feature", kw.feature}, {"background", kw.background},
+		{"examples", kw.examples}, {"rule", kw.rule}, {"scenario", kw.scenario},
+	} {
+		for _, k := range c.list {
+			if strings.EqualFold(head, k) {
+				return c.kind, title
+			}
+		}
+	}
+	return "", ""
+}
+
+// gherkinStepKeyword devolve (keyword, texto) se a linha é um passo.
+func gherkinStepKeyword(kw gherkinKeywords, line string) (string, string, bool) {
+	best := ""
+	for _, k := range kw.steps {
+		if k == "*" {
+			if strings.HasPrefix(line, "* ") && len(best) < 1 {
+				best = k
+			}
+			continue
+		}
+		if len(line) > len(k) && strings.HasPrefix(line, k) && line[len(k)] == ' ' && len(k) > len(best) {
+			best = k
+		}
+	}
+	if best == "" {
+		return "", "", false
+	}
+	return best, strings.TrimSpace(line[len(best):]), true
+}
+
+// mergedKeywords une inglês e o idioma pedido (ou todos, se desconhecido).
+func mergedKeywords(lang string) gherkinKeywords {
+	langs := []string{"en"}
+	if _, ok := gherkinLanguages[lang]; ok && lang != "en" {
+		langs = append(langs, lang)
+	} else if !ok {
+		for l := range gherkinLanguages {
+			if l != "en" {
+				langs = append(langs, l)
+			}
+		}
+	}
+	var out gherkinKeywords
+	for _, l := range langs {
+		k := gherkinLanguages[l]
+		out.feature = append(out.feature, k.feature...)
+		out.background = append(out.background, k.background...)
+		out.scenario = append(out.scenario, k.scenario...)
+		out.outline = append(out.outline, k.outline...)
+		out.examples = append(out.examples, k.examples...)
+		out.rule = append(out.rule, k.rule...)
+		out.steps = append(out.steps, k.steps...)
+	}
+	return out
+}
+
+// parseGherkin faz um parse leve (linha a linha) de um arquivo .feature.
+func parseGherkin(source *files.Source, path, rel string, maxBytes int64) (*GherkinFeature, error) {
+	head, err := source.ReadHead(path, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(head, "\n")
+
+	gf := &GherkinFeature{File: rel, Language: "en", Capability: featureCapability(rel)}
+	// "# language: xx" só vale antes da primeira linha significativa
+	for _, ln := range lines {
+		t := strings.TrimSpace(ln)
+		if t == "" {
+			continue
+		}
+		if strings.HasPrefix(t, "#") {
+			c := strings.TrimSpace(strings.TrimPrefix(t, "#"))
+			if strings.HasPrefix(strings.ToLower(c), "language:") {
+				gf.Language = strings.TrimSpace(c[len("language:"):])
+			}
+			continue
+		}
+		break
+	}
+	kw := mergedKeywords(gf.Language)
+
+	var (
+		pendingTags []string
+		block       string // feature | background | scenario | outline | examples | rule
+		rule        string
+		ruleTags    []string
+		cur         *GherkinScenario
+		desc        []string
+		docString   string // delimitador aberto (""" ou ```)
+		header      []string
+	)
+	flush := func() {
+		if cur != nil {
+			gf.Scenarios = append(gf.Scenarios, *cur)
+			cur = nil
+		}
+	}
+	for i, raw := range lines {
+		t := strings.TrimSpace(raw)
+		if docString != "" {
+			if strings.HasPrefix(t, docString) {
+				docString = ""
+			}
+			continue
+		}
+		if t == "" || strings.HasPrefix(t, "#") {
+			continue
+		}
+		if strings.HasPrefix(t, `"""`) || strings.HasPrefix(t, "```") {
+			docString = t[:3]
+			continue
+		}
+		if strings.HasPrefix(t, "@") {
+			for _, tag := range strings.Fields(t) {
+				if strings.HasPrefix(tag, "#") {
+					break // comentário no fim da linha
+				}
+				pendingTags = append(pendingTags, tag)
+			}
+			continue
+		}
+		if strings.HasPrefix(t, "|") {
+			if block == "examples" && cur != nil {
+				cells := splitGherkinRow(t)
+				if header == nil {
+					header = cells
+				} else {
+					cur.Examples++
+					row := map[string]string{}
+					for j, h := range header {
+						if j < len(cells) {
+							row[h] = cells[j]
+						}
+					}
+					cur.exampleRows = append(cur.exampleRows, row)
+				}
+			}
+			continue // tabelas de dados de passos não interessam aqui
+		}
+
+		if kind, title := gherkinBlockKind(kw, t); kind != "" {
+			switch kind {
+			case "feature":
+				gf.Name = title
+				gf.Tags = pendingTags
+			case "rule":
+				flush()
+				rule, ruleTags = title, pendingTags
+			case "background":
+				flush()
+				gf.HasBackground = true
+			case "scenario", "outline":
+				flush()
+				sk := "scenario"
+				if kind == "outline" {
+					sk = "outline"
+				}
+				cur = &GherkinScenario{Name: title, Kind: sk, Line: i + 1, Rule: rule, Tags: pendingTags, ruleTags: ruleTags}
+			case "examples":
+				header = nil
+				if cur != nil && cur.Kind == "scenario" {
+					// "Scenario" com Examples se comporta como outline (Gherkin 6+)
+					cur.Kind = "outline"
+				}
+			}
+			block = kind
+			pendingTags = nil
+			continue
+		}
+
+		switch block {
+		case "feature", "rule":
+			if block == "feature" && len(desc) < 3 {
+				desc = append(desc, t)
+			}
+		case "background", "scenario", "outline":
+			if k, text, ok := gherkinStepKeyword(kw, t); ok {
+				st := GherkinStep{Keyword: k, Text: text, Line: i + 1}
+				if block == "background" {
+					gf.BackgroundSteps = append(gf.BackgroundSteps, st)
+				} else if cur != nil {
+					cur.Steps = append(cur.Steps, st)
+				}
+			}
+		}
+	}
+	flush()
+	gf.Description = limitLine(strings.Join(desc, " "), 300)
+	if gf.Name == "" && len(gf.Scenarios) == 0 {
+		return nil, errNotGherkin
+	}
+	return gf, nil
+}
+
+// errNotGherkin sinaliza que o arquivo não tem Feature nem cenários reconhecíveis.
+var errNotGherkin = errors.New("not a gherkin feature")
+
+func splitGherkinRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+	parts := strings.Split(line, "|")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
+// featureCapability deriva a "capacidade" do caminho: o diretório abaixo de
+// features/ (features/agents/create.feature → "agents"), o diretório que contém
+// features/ (services/agent/features/x.feature → "agent") ou o nome do arquivo.
+func featureCapability(rel string) string {
+	segs := strings.Split(filepath.ToSlash(filepath.Dir(rel)), "/")
+	idx := -1
+	for i, s := range segs {
+		if strings.EqualFold(s, "features") || strings.EqualFold(s, "specs") {
+			idx = i
+		}
+	}
+	if idx >= 0 {
+		if after := segs[idx+1:]; len(after) > 0 {
+			return strings.Join(after, "/")
+		}
+		if idx > 0 {
+			return segs[idx-1]
+		}
+	}
+	base := filepath.Base(rel)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
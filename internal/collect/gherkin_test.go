@@ -0,0 +1,131 @@
+package collect
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseGherkin(t *testing.T) {
+	type scenario struct {
+		Name, Kind, Rule string
+		Line, Examples   int
+		Tags, Effective  []string
+		Steps            []string
+	}
+	tests := []struct {
+		name       string
+		rel        string
+		src        string
+		lang       string
+		capability string
+		feature    string
+		tags       []string
+		background bool
+		scenarios  []scenario
+		err        error
+	}{
+		{
+			name: "en com rule, outline e doc string",
+			rel:  "features/agents/create.feature",
+			src: `@api @wip # fim de linha
+Feature: Create agents
+  As an operator
+  I want agents
+
+  Background:
+    Given a tenant
+
+  @smoke
+  Scenario: happy path
+    When I create "a"
+      """
+      Scenario: dentro da doc string
+      """
+    Then it exists
+
+  Rule: limits
+    @slow
+    Scenario Outline: too many
+      Given <n> agents
+      Examples:
+        | n |
+        | 10 |
+        | 20 |
+
+    Example: with examples acts as outline
+      Then fail
+      Examples:
+        | x |
+        | 1 |
+`,
+			lang: "en", capability: "agents", feature: "Create agents",
+			tags: []string{"@api", "@wip"}, background: true,
+			scenarios: []scenario{
+				{Name: "happy path", Kind: "scenario", Line: 10, Tags: []string{"@smoke"},
+					Effective: []string{"@api", "@wip", "@smoke"}, Steps: []string{"When I create \"a\"", "Then it exists"}},
+				{Name: "too many", Kind: "outline", Rule: "limits", Line: 19, Examples: 2, Tags: []string{"@slow"},
+					Effective: []string{"@api", "@wip", "@slow"}, Steps: []string{"Given <n> agents"}},
+				{Name: "with examples acts as outline", Kind: "outline", Rule: "limits", Line: 26, Examples: 1,
+					Effective: []string{"@api", "@wip"}, Steps: []string{"Then fail"}},
+			},
+		},
+		{
+			name: "pt via # language",
+			rel:  "specs/login.feature",
+			src: `# language: pt
+Funcionalidade: Login
+
+  Cenário: senha certa
+    Dado um usuário
+    Quando ele entra
+    E confirma
+    Então vê o painel
+`,
+			lang: "pt", capability: "login", feature: "Login",
+			scenarios: []scenario{
+				{Name: "senha certa", Kind: "scenario", Line: 4,
+					Steps: []string{"Dado um usuário", "Quando ele entra", "E confirma", "Então vê o painel"}},
+			},
+		},
+		{
+			name: "sem feature nem cenários",
+			rel:  "notes.feature",
+			src:  "# rascunho\nalguma coisa\n",
+			err:  errNotGherkin,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gf, err := parseGherkin(nil, writeTemp(t, "f.feature", tt.src), tt.rel, 1<<20)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if err != nil {
+				return
+			}
+			if gf.Language != tt.lang || gf.Capability != tt.capability || gf.Name != tt.feature || gf.HasBackground != tt.background {
+				t.Errorf("feature = {%q %q %q bg=%v}, want {%q %q %q bg=%v}",
+					gf.Language, gf.Capability, gf.Name, gf.HasBackground, tt.lang, tt.capability, tt.feature, tt.background)
+			}
+			if !reflect.DeepEqual(gf.Tags, tt.tags) {
+				t.Errorf("tags = %v, want %v", gf.Tags, tt.tags)
+			}
+			var got []scenario
+			for _, sc := range gf.Scenarios {
+				s := scenario{Name: sc.Name, Kind: sc.Kind, Rule: sc.Rule, Line: sc.Line, Examples: sc.Examples,
+					Tags: sc.Tags, Effective: gf.EffectiveTags(sc)}
+				if len(s.Effective) == 0 {
+					s.Effective = nil
+				}
+				for _, st := range sc.Steps {
+					s.Steps = append(s.Steps, st.Keyword+" "+st.Text)
+				}
+				got = append(got, s)
+			}
+			if !reflect.DeepEqual(got, tt.scenarios) {
+				t.Errorf("scenarios =\n%+v\nwant\n%+v", got, tt.scenarios)
+			}
+		})
+	}
+}
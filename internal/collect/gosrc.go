@@ -0,0 +1,390 @@
+package collect
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// goSource é um arquivo .go lido e parseado uma única vez; os analisadores
+// (testes, step definitions, rotas...) trabalham sobre o mesmo AST.
+type goSource struct {
+	Rel     string // caminho relativo à raiz (com "/")
+	Dir     string // diretório do pacote (path.Dir(Rel))
+	Src     []byte
+	Fset    *token.FileSet
+	File    *ast.File
+	Imports map[string]string // import path -> nome local (alias ou último elemento)
+}
+
+// parseGoSource lê e parseia um arquivo Go (com comentários). Erros de sintaxe
+// não são fatais: o AST parcial ainda é útil para heurísticas.
+func parseGoSource(source *files.Source, full, rel string) (*goSource, error) {
+	src, err := source.ReadFile(full)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, rel, src, parser.ParseComments|parser.SkipObjectResolution)
+	if f == nil {
+		return nil, err
+	}
+	gs := &goSource{Rel: rel, Dir: path.Dir(rel), Src: src, Fset: fset, File: f, Imports: map[string]string{}}
+	for _, imp := range f.Imports {
+		p, uerr := strconv.Unquote(imp.Path.Value)
+		if uerr != nil {
+			continue
+		}
+		name := importBaseName(p)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		gs.Imports[p] = name
+	}
+	return gs, nil
+}
+
+// IsTest indica se é um arquivo _test.go.
+func (gs *goSource) IsTest() bool { return strings.HasSuffix(gs.Rel, "_test.go") }
+
+// Package devolve o nome do pacote declarado.
+func (gs *goSource) Package() string { return gs.File.Name.Name }
+
+// Line devolve a linha de uma posição do arquivo.
+func (gs *goSource) Line(p token.Pos) int { return gs.Fset.Position(p).Line }
+
+// importsAny diz se o arquivo importa algum dos paths (ou prefixos terminados em "/").
+func (gs *goSource) importsAny(paths ...string) bool {
+	for imp := range gs.Imports {
+		for _, p := range paths {
+			if imp == p || (strings.HasSuffix(p, "/") && strings.HasPrefix(imp, p)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// importBaseName deduz o nome de pacote padrão de um import path
+// (ignora sufixos de versão "/v2" e "gopkg.in/x.v3").
+func importBaseName(p string) string {
+	base := path.Base(p)
+	if len(base) >= 2 && base[0] == 'v' && strings.Trim(base[1:], "0123456789") == "" {
+		if dir := path.Dir(p); dir != "." {
+			base = path.Base(dir)
+		}
+	}
+	if i := strings.Index(base, ".v"); i > 0 && strings.HasPrefix(p, "gopkg.in/") {
+		base = base[:i]
+	}
+	base = strings.TrimPrefix(base, "go-")
+	base = strings.TrimSuffix(base, ".go")
+	base = strings.TrimSuffix(base, "-go")
+	return strings.ReplaceAll(base, "-", "_")
+}
+
+// importPathOf devolve o import path associado a um nome local ("nats" -> github.com/nats-io/nats.go).
+func (gs *goSource) importPathOf(local string) (string, bool) {
+	for p, name := range gs.Imports {
+		if name == local {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// funcDeclName devolve "Func" ou "Tipo.Metodo" para uma declaração de função.
+func funcDeclName(fd *ast.FuncDecl) string {
+	if fd.Recv != nil && len(fd.Recv.List) > 0 {
+		if recv, _ := receiverName(fd.Recv.List[0].Type); recv != "" {
+			return strings.TrimPrefix(recv, "*") + "." + fd.Name.Name
+		}
+	}
+	return fd.Name.Name
+}
+
+// stringConsts coleta const/var de pacote com valor string estático
+// (valores podem conter marcadores ${...} resolvidos depois, entre arquivos).
+func (gs *goSource) stringConsts() map[string]string {
+	out := map[string]string{}
+	for _, d := range gs.File.Decls {
+		gd, ok := d.(*ast.GenDecl)
+		if !ok || (gd.Tok != token.CONST && gd.Tok != token.VAR) {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || len(vs.Values) != len(vs.Names) {
+				continue
+			}
+			for i, n := range vs.Names {
+				if isStaticString(vs.Values[i]) {
+					out[n.Name] = gs.evalString(vs.Values[i], out)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// localStringConsts coleta x := "..." / const x = "..." do corpo de uma função.
+func (gs *goSource) localStringConsts(body *ast.BlockStmt, pkg map[string]string) map[string]string {
+	out := make(map[string]string, len(pkg))
+	for k, v := range pkg {
+		out[k] = v
+	}
+	if body == nil {
+		return out
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			if len(x.Lhs) == len(x.Rhs) {
+				for i, l := range x.Lhs {
+					if id, ok := l.(*ast.Ident); ok && isStaticString(x.Rhs[i]) {
+						out[id.Name] = gs.evalString(x.Rhs[i], out)
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			if len(x.Values) == len(x.Names) {
+				for i, id := range x.Names {
+					if isStaticString(x.Values[i]) {
+						out[id.Name] = gs.evalString(x.Values[i], out)
+					}
+				}
+			}
+		}
+		return true
+	})
+	return out
+}
+
+// isStaticString diz se a expressão tem cara de string estática
+// (literal, concatenação, fmt.Sprintf ou referência a outra constante).
+func isStaticString(e ast.Expr) bool {
+	switch x := e.(type) {
+	case *ast.BasicLit:
+		return x.Kind == token.STRING
+	case *ast.BinaryExpr:
+		return x.Op == token.ADD && (isStaticString(x.X) || isStaticString(x.Y))
+	case *ast.CallExpr:
+		return exprString(x.Fun) == "fmt.Sprintf"
+	case *ast.ParenExpr:
+		return isStaticString(x.X)
+	}
+	return false
+}
+
+var printfVerbRe = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z]`)
+
+// evalString avalia uma expressão string de forma estática. Identificadores
+// sem valor local viram ${Nome} (constante do pacote) ou ${import/path.Nome};
+// o resto vira {expr}. Verbos de fmt.Sprintf viram "*".
+func (gs *goSource) evalString(e ast.Expr, consts map[string]string) string {
+	switch x := e.(type) {
+	case *ast.BasicLit:
+		if x.Kind == token.STRING {
+			if s, err := strconv.Unquote(x.Value); err == nil {
+				return s
+			}
+		}
+		return x.Value
+	case *ast.Ident:
+		if v, ok := consts[x.Name]; ok {
+			return v
+		}
+		return "${" + x.Name + "}"
+	case *ast.SelectorExpr:
+		if id, ok := x.X.(*ast.Ident); ok {
+			if p, ok := gs.importPathOf(id.Name); ok {
+				return "${" + p + "." + x.Sel.Name + "}"
+			}
+		}
+	case *ast.BinaryExpr:
+		if x.Op == token.ADD {
+			return gs.evalString(x.X, consts) + gs.evalString(x.Y, consts)
+		}
+	case *ast.ParenExpr:
+		return gs.evalString(x.X, consts)
+	case *ast.UnaryExpr:
+		if x.Op == token.AND {
+			return gs.evalString(x.X, consts)
+		}
+	case *ast.CallExpr:
+		if exprString(x.Fun) == "fmt.Sprintf" && len(x.Args) > 0 {
+			return printfVerbRe.ReplaceAllString(gs.evalString(x.Args[0], consts), "*")
+		}
+	}
+	return "{" + exprString(e) + "}"
+}
+
+var placeholderRe = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// goPackage resume um pacote Go do repo (arquivos não-teste de um diretório).
+type goPackage struct {
+	Name    string
+	Imports map[string]bool
+	Consts  map[string]string // const/var string de pacote (ver stringConsts)
+}
+
+// addGoPackageFile acumula nome, imports e constantes de um arquivo no pacote do diretório.
+func addGoPackageFile(pkgs map[string]*goPackage, gs *goSource, consts map[string]string) {
+	pkg := pkgs[gs.Dir]
+	if pkg == nil {
+		pkg = &goPackage{Name: gs.Package(), Imports: map[string]bool{}, Consts: map[string]string{}}
+		pkgs[gs.Dir] = pkg
+	}
+	for p := range gs.Imports {
+		pkg.Imports[p] = true
+	}
+	for k, v := range consts {
+		pkg.Consts[k] = v
+	}
+}
+
+// goPackageIndex resolve imports locais para diretórios e marcadores ${...}
+// (deixados por evalString) para as constantes do pacote certo.
+type goPackageIndex struct {
+	pkgs map[string]*goPackage // dir relativo -> pacote
+	mods []GoModule
+	root string
+}
+
+func newGoPackageIndex(pkgs map[string]*goPackage, mods []GoModule, root string) *goPackageIndex {
+	ix := &goPackageIndex{pkgs: pkgs, mods: mods, root: root}
+	for dir, pkg := range pkgs {
+		for k, v := range pkg.Consts {
+			if strings.Contains(v, "${") {
+				pkg.Consts[k] = ix.resolve(v, dir)
+			}
+		}
+	}
+	return ix
+}
+
+// importDir devolve o diretório (relativo à raiz) de um import de módulo do repo, ou "".
+func (ix *goPackageIndex) importDir(p string) string {
+	dir := resolveGoImportFile(ix.mods, ix.root, p)
+	if _, ok := ix.pkgs[dir]; !ok {
+		return ""
+	}
+	return dir
+}
+
+// resolve troca ${Nome} / ${import/path.Nome} pelas constantes coletadas;
+// o que não resolver vira {Nome}.
+func (ix *goPackageIndex) resolve(s, dir string) string {
+	for i := 0; i < 5 && strings.Contains(s, "${"); i++ {
+		s = placeholderRe.ReplaceAllStringFunc(s, func(m string) string {
+			ref := m[2 : len(m)-1]
+			d, name := dir, ref
+			if j := strings.LastIndex(ref, "."); j > 0 && strings.Contains(ref[:j], "/") {
+				d, name = ix.importDir(ref[:j]), ref[j+1:]
+			}
+			if pkg := ix.pkgs[d]; pkg != nil {
+				if v, ok := pkg.Consts[name]; ok && v != m {
+					return v
+				}
+			}
+			return "{" + name + "}"
+		})
+	}
+	return s
+}
+
+var generatedRe = regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+// IsGenerated segue a convenção do Go: comentário "// Code generated ... DO NOT EDIT."
+// antes da cláusula package.
+func (gs *goSource) IsGenerated() bool {
+	end := gs.Fset.Position(gs.File.Package).Offset
+	if end > len(gs.Src) {
+		end = len(gs.Src)
+	}
+	return generatedRe.Match(gs.Src[:end])
+}
+
+var predeclaredTypes = map[string]bool{"bool": true, "byte": true, "complex64": true, "complex128": true,
+	"error": true, "float32": true, "float64": true, "int": true, "int8": true, "int16": true, "int32": true,
+	"int64": true, "rune": true, "string": true, "uint": true, "uint8": true, "uint16": true, "uint32": true,
+	"uint64": true, "uintptr": true, "any": true, "comparable": true}
+
+// qualifiedType escreve um tipo com o pacote explícito entre ⟦⟧: ⟦import/path⟧.T
+// para tipos importados e ⟦dir:pkg/dir⟧.T para tipos do próprio pacote.
+// A forma canônica final vem de goPackageIndex.canonicalType.
+func (gs *goSource) qualifiedType(e ast.Expr) string {
+	switch x := e.(type) {
+	case *ast.Ident:
+		if predeclaredTypes[x.Name] {
+			return x.Name
+		}
+		return "⟦dir:" + gs.Dir + "⟧." + x.Name
+	case *ast.SelectorExpr:
+		if id, ok := x.X.(*ast.Ident); ok {
+			if p, ok := gs.importPathOf(id.Name); ok {
+				return "⟦" + p + "⟧." + x.Sel.Name
+			}
+		}
+		return exprString(x)
+	case *ast.StarExpr:
+		return "*" + gs.qualifiedType(x.X)
+	case *ast.ArrayType:
+		if x.Len == nil {
+			return "[]" + gs.qualifiedType(x.Elt)
+		}
+		return "[" + exprString(x.Len) + "]" + gs.qualifiedType(x.Elt)
+	case *ast.MapType:
+		return "map[" + gs.qualifiedType(x.Key) + "]" + gs.qualifiedType(x.Value)
+	case *ast.Ellipsis:
+		return "..." + gs.qualifiedType(x.Elt)
+	case *ast.ChanType:
+		return "chan " + gs.qualifiedType(x.Value)
+	case *ast.IndexExpr:
+		return gs.qualifiedType(x.X) + "[" + gs.qualifiedType(x.Index) + "]"
+	case *ast.FuncType:
+		return "func(…)"
+	case *ast.InterfaceType:
+		return "interface{…}"
+	case *ast.StructType:
+		return "struct{…}"
+	case *ast.ParenExpr:
+		return gs.qualifiedType(x.X)
+	}
+	return exprString(e)
+}
+
+var qualifierRe = regexp.MustCompile(`⟦([^⟧]*)⟧`)
+
+// canonicalType troca os qualificadores ⟦⟧ pelo diretório do pacote (imports
+// locais) ou pelo import path (externos). Devolve também a forma curta
+// ("user.Service") para exibição.
+func (ix *goPackageIndex) canonicalType(t string) (canon, short string) {
+	canon = qualifierRe.ReplaceAllStringFunc(t, func(m string) string {
+		p := m[len("⟦") : len(m)-len("⟧")]
+		if dir, ok := strings.CutPrefix(p, "dir:"); ok {
+			return dir
+		}
+		if dir := ix.importDir(p); dir != "" {
+			return dir
+		}
+		return p
+	})
+	short = qualifierRe.ReplaceAllStringFunc(t, func(m string) string {
+		p := m[len("⟦") : len(m)-len("⟧")]
+		if dir, ok := strings.CutPrefix(p, "dir:"); ok {
+			if pkg := ix.pkgs[dir]; pkg != nil {
+				return pkg.Name
+			}
+			return path.Base(dir)
+		}
+		return importBaseName(p)
+	})
+	return canon, short
+}
@@ -0,0 +1,498 @@
+package collect
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// GraphQLSchema resume um arquivo SDL (.graphql/.graphqls/.gql).
+type GraphQLSchema struct {
+	File          string         `json:"file"`
+	Types         []GraphQLType  `json:"types,omitempty"`
+	Queries       []GraphQLField `json:"queries,omitempty"`
+	Mutations     []GraphQLField `json:"mutations,omitempty"`
+	Subscriptions []GraphQLField `json:"subscriptions,omitempty"`
+	Directives    []string       `json:"directives,omitempty"`     // diretivas usadas (@auth, @deprecated...)
+	DirectiveDefs []string       `json:"directive_defs,omitempty"` // diretivas declaradas (directive @x on ...)
+
+	objects map[string][]GraphQLField // campos de type/extend type, para resolver as raízes
+	roots   map[string]string         // schema { query: X } -> operação => tipo
+}
+
+// GraphQLType é uma definição nomeada do SDL (exceto as raízes de operação).
+type GraphQLType struct {
+	Name   string `json:"name"`
+	Kind   string `json:"kind"` // type | input | interface | enum | union | scalar
+	Fields int    `json:"fields,omitempty"`
+}
+
+// GraphQLField é um campo de Query/Mutation/Subscription.
+type GraphQLField struct {
+	Name    string `json:"name"`
+	Args    string `json:"args,omitempty"` // "id: ID!, first: Int"
+	Returns string `json:"returns"`
+}
+
+// GqlgenConfig mapeia um gqlgen.yml para os schemas e pacotes gerados.
+type GqlgenConfig struct {
+	File            string            `json:"file"`
+	SchemaGlobs     []string          `json:"schema_globs"`
+	Schemas         []string          `json:"schemas,omitempty"` // arquivos SDL que casam com os globs
+	ExecPackage     string            `json:"exec_package,omitempty"`
+	ModelPackage    string            `json:"model_package,omitempty"`
+	ResolverDir     string            `json:"resolver_dir,omitempty"`
+	ResolverPackage string            `json:"resolver_package,omitempty"`
+	ResolverLayout  string            `json:"resolver_layout,omitempty"` // single-file | follow-schema
+	Resolvers       map[string]string `json:"resolvers,omitempty"`       // schema -> arquivo de resolvers
+	Autobind        []string          `json:"autobind,omitempty"`
+}
+
+func isGraphQLFile(lower string) bool {
+	switch path.Ext(lower) {
+	case ".graphql", ".graphqls", ".gql":
+		return true
+	}
+	return false
+}
+
+func isGqlgenConfig(lower string) bool {
+	switch path.Base(lower) {
+	case "gqlgen.yml", "gqlgen.yaml", ".gqlgen.yml":
+		return true
+	}
+	return false
+}
+
+type gqlToken struct {
+	kind byte // n = nome, s = string, p = pontuação
+	val  string
+}
+
+// lexGraphQL quebra o SDL em tokens, descartando comentários e vírgulas.
+func lexGraphQL(src string) []gqlToken {
+	var toks []gqlToken
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(src[i:], `"""`):
+			end := strings.Index(src[i+3:], `"""`)
+			if end < 0 {
+				return toks
+			}
+			toks = append(toks, gqlToken{'s', src[i+3 : i+3+end]})
+			i += end + 6
+		case c == '"':
+			j := i + 1
+			for j < len(src) && src[j] != '"' && src[j] != '\n' {
+				if src[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j > len(src) {
+				j = len(src) // string não terminada
+			}
+			toks = append(toks, gqlToken{'s', src[i+1 : j]})
+			i = j + 1
+		case strings.HasPrefix(src[i:], "..."):
+			toks = append(toks, gqlToken{'p', "..."})
+			i += 3
+		case strings.ContainsRune("{}()[]:!=@|&$", rune(c)):
+			toks = append(toks, gqlToken{'p', string(c)})
+			i++
+		default:
+			j := i
+			for j < len(src) && (src[j] == '_' || src[j] == '-' || src[j] == '.' ||
+				(src[j] >= 'a' && src[j] <= 'z') || (src[j] >= 'A' && src[j] <= 'Z') || (src[j] >= '0' && src[j] <= '9')) {
+				j++
+			}
+			if j == i {
+				j++ // caractere desconhecido
+			}
+			toks = append(toks, gqlToken{'n', src[i:j]})
+			i = j
+		}
+	}
+	return toks
+}
+
+type gqlParser struct {
+	toks       []gqlToken
+	i          int
+	directives map[string]bool
+}
+
+func (p *gqlParser) peek() gqlToken {
+	if p.i < len(p.toks) {
+		return p.toks[p.i]
+	}
+	return gqlToken{}
+}
+
+func (p *gqlParser) next() gqlToken {
+	t := p.peek()
+	if p.i < len(p.toks) {
+		p.i++
+	}
+	return t
+}
+
+func (p *gqlParser) is(val string) bool {
+	t := p.peek()
+	return t.kind == 'p' && t.val == val
+}
+
+func (p *gqlParser) skipStrings() {
+	for p.peek().kind == 's' {
+		p.i++
+	}
+}
+
+// skipBalanced consome de "(" / "[" / "{" até o fechamento correspondente.
+func (p *gqlParser) skipBalanced() {
+	depth := 0
+	for p.i < len(p.toks) {
+		t := p.next()
+		if t.kind != 'p' {
+			continue
+		}
+		switch t.val {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+		}
+		if depth <= 0 {
+			return
+		}
+	}
+}
+
+func (p *gqlParser) directiveUses() {
+	for p.is("@") {
+		p.next()
+		p.directives["@"+p.next().val] = true
+		if p.is("(") {
+			p.skipBalanced()
+		}
+	}
+}
+
+// typeRef lê uma referência de tipo: Name, Name!, [Name!]!
+func (p *gqlParser) typeRef() string {
+	var b strings.Builder
+	if p.is("[") {
+		p.next()
+		b.WriteString("[" + p.typeRef())
+		if p.is("]") {
+			p.next()
+		}
+		b.WriteString("]")
+	} else {
+		b.WriteString(p.next().val)
+	}
+	if p.is("!") {
+		p.next()
+		b.WriteString("!")
+	}
+	return b.String()
+}
+
+func (p *gqlParser) args() string {
+	if !p.is("(") {
+		return ""
+	}
+	p.next()
+	var out []string
+	for p.i < len(p.toks) && !p.is(")") {
+		p.skipStrings()
+		if p.is(")") {
+			break
+		}
+		name := p.next().val
+		if !p.is(":") {
+			continue
+		}
+		p.next()
+		arg := name + ": " + p.typeRef()
+		if p.is("=") {
+			p.next()
+			if p.is("[") || p.is("{") {
+				p.skipBalanced()
+			} else {
+				p.next()
+			}
+		}
+		p.directiveUses()
+		out = append(out, arg)
+	}
+	p.next() // ")"
+	return strings.Join(out, ", ")
+}
+
+func (p *gqlParser) fields() []GraphQLField {
+	var out []GraphQLField
+	if !p.is("{") {
+		return nil
+	}
+	p.next()
+	for p.i < len(p.toks) && !p.is("}") {
+		p.skipStrings()
+		if p.is("}") {
+			break
+		}
+		t := p.next()
+		if t.kind != 'n' {
+			continue
+		}
+		f := GraphQLField{Name: t.val, Args: p.args()}
+		if p.is(":") {
+			p.next()
+			f.Returns = p.typeRef()
+		}
+		p.directiveUses()
+		out = append(out, f)
+	}
+	p.next() // "}"
+	return out
+}
+
+// parseGraphQL extrai definições, campos das raízes e diretivas de um SDL.
+func parseGraphQL(source *files.Source, full, rel string, maxBytes int64) (*GraphQLSchema, error) {
+	head, err := source.ReadHead(full, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	gs := &GraphQLSchema{File: rel, objects: map[string][]GraphQLField{}, roots: map[string]string{}}
+	p := &gqlParser{toks: lexGraphQL(head), directives: map[string]bool{}}
+	kinds := map[string]int{} // nome -> índice em gs.Types
+	for p.i < len(p.toks) {
+		p.skipStrings()
+		t := p.next()
+		if t.kind != 'n' {
+			continue
+		}
+		kw := t.val
+		if kw == "extend" {
+			kw = p.next().val
+		}
+		switch kw {
+		case "schema":
+			p.directiveUses()
+			if p.is("{") {
+				p.next()
+				for p.i < len(p.toks) && !p.is("}") {
+					op := p.next().val
+					if p.is(":") {
+						p.next()
+						gs.roots[op] = p.next().val
+					}
+				}
+				p.next()
+			}
+		case "type", "interface", "input":
+			name := p.next().val
+			for p.i < len(p.toks) && !p.is("{") && !p.is("@") && p.peek().kind != 's' && !isGraphQLKeyword(p.peek().val) {
+				p.next() // implements A & B
+			}
+			p.directiveUses()
+			fs := p.fields()
+			if kw == "type" {
+				gs.objects[name] = append(gs.objects[name], fs...)
+			}
+			gs.addType(kinds, name, kw, len(fs))
+		case "enum":
+			name := p.next().val
+			p.directiveUses()
+			n := 0
+			if p.is("{") {
+				p.next()
+				for p.i < len(p.toks) && !p.is("}") {
+					if tk := p.next(); tk.kind == 'n' {
+						n++
+					}
+					p.directiveUses()
+				}
+				p.next()
+			}
+			gs.addType(kinds, name, "enum", n)
+		case "union":
+			name := p.next().val
+			p.directiveUses()
+			n := 0
+			if p.is("=") {
+				p.next()
+				for p.is("|") || p.peek().kind == 'n' && !isGraphQLKeyword(p.peek().val) {
+					if p.next().kind == 'n' {
+						n++
+					}
+				}
+			}
+			gs.addType(kinds, name, "union", n)
+		case "scalar":
+			name := p.next().val
+			p.directiveUses()
+			gs.addType(kinds, name, "scalar", 0)
+		case "directive":
+			if p.is("@") {
+				p.next()
+			}
+			gs.DirectiveDefs = append(gs.DirectiveDefs, "@"+p.next().val)
+			if p.is("(") {
+				p.skipBalanced()
+			}
+			for p.i < len(p.toks) && !isGraphQLDefinitionStart(p.peek()) {
+				p.next() // repeatable on FIELD_DEFINITION | OBJECT
+			}
+		}
+	}
+	for d := range p.directives {
+		gs.Directives = append(gs.Directives, d)
+	}
+	sort.Strings(gs.Directives)
+	sort.Strings(gs.DirectiveDefs)
+	return gs, nil
+}
+
+func (gs *GraphQLSchema) addType(index map[string]int, name, kind string, fields int) {
+	if i, ok := index[name]; ok {
+		gs.Types[i].Fields += fields // extend type
+		return
+	}
+	index[name] = len(gs.Types)
+	gs.Types = append(gs.Types, GraphQLType{Name: name, Kind: kind, Fields: fields})
+}
+
+func isGraphQLKeyword(s string) bool {
+	switch s {
+	case "type", "interface", "input", "enum", "union", "scalar", "directive", "schema", "extend":
+		return true
+	}
+	return false
+}
+
+func isGraphQLDefinitionStart(t gqlToken) bool {
+	return t.kind == 's' || t.kind == 'n' && isGraphQLKeyword(t.val)
+}
+
+// resolveGraphQLRoots preenche Queries/Mutations/Subscriptions de cada
+// arquivo, usando "schema { query: X }" de qualquer arquivo do repo (schemas
+// divididos em vários .graphqls são comuns com gqlgen).
+func resolveGraphQLRoots(schemas []GraphQLSchema) {
+	roots := map[string]string{"query": "Query", "mutation": "Mutation", "subscription": "Subscription"}
+	for _, s := range schemas {
+		for op, t := range s.roots {
+			roots[op] = t
+		}
+	}
+	for i := range schemas {
+		s := &schemas[i]
+		s.Queries = s.objects[roots["query"]]
+		s.Mutations = s.objects[roots["mutation"]]
+		s.Subscriptions = s.objects[roots["subscription"]]
+		rootTypes := map[string]bool{roots["query"]: true, roots["mutation"]: true, roots["subscription"]: true}
+		var types []GraphQLType
+		for _, t := range s.Types {
+			if !rootTypes[t.Name] {
+				types = append(types, t)
+			}
+		}
+		s.Types = types
+	}
+	sort.Slice(schemas, func(i, j int) bool { return schemas[i].File < schemas[j].File })
+}
+
+// parseGqlgenConfig lê gqlgen.yml (schema, exec, model, resolver, autobind).
+func parseGqlgenConfig(source *files.Source, full, rel string, maxBytes int64) (*GqlgenConfig, error) {
+	head, err := source.ReadHead(full, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if err := yaml.Unmarshal([]byte(head), &doc); err != nil {
+		return nil, err
+	}
+	gc := &GqlgenConfig{File: rel}
+	gc.SchemaGlobs = toStrings(getPath(doc, "schema"))
+	if len(gc.SchemaGlobs) == 0 {
+		gc.SchemaGlobs = []string{"schema.graphql"} // padrão do gqlgen
+	}
+	gc.ExecPackage = gqlgenPackage(getPath(doc, "exec"))
+	gc.ModelPackage = gqlgenPackage(getPath(doc, "model"))
+	gc.ResolverDir = settingString(getPath(doc, "resolver.dir"))
+	if gc.ResolverDir == "" {
+		if fn := settingString(getPath(doc, "resolver.filename")); fn != "" {
+			gc.ResolverDir = path.Dir(fn)
+		}
+	}
+	gc.ResolverPackage = settingString(getPath(doc, "resolver.package"))
+	gc.ResolverLayout = settingString(getPath(doc, "resolver.layout"))
+	if gc.ResolverLayout == "" && getPath(doc, "resolver") != nil {
+		gc.ResolverLayout = "single-file"
+	}
+	gc.Autobind = toStrings(getPath(doc, "autobind"))
+	return gc, nil
+}
+
+func gqlgenPackage(v any) string {
+	if pkg := settingString(getPath(v, "package")); pkg != "" {
+		return pkg
+	}
+	if fn := settingString(getPath(v, "filename")); fn != "" {
+		return path.Dir(fn)
+	}
+	return settingString(getPath(v, "dir"))
+}
+
+// linkGqlgen casa os globs de schema com os SDL varridos e, no layout
+// follow-schema, aponta o <schema>.resolvers.go de cada arquivo.
+func linkGqlgen(cfgs []GqlgenConfig, schemas []GraphQLSchema, paths []string) {
+	pathSet := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		pathSet[p] = true
+	}
+	for i := range cfgs {
+		gc := &cfgs[i]
+		prefix := dirPrefix(path.Dir(gc.File))
+		for _, s := range schemas {
+			if !strings.HasPrefix(s.File, prefix) {
+				continue
+			}
+			if matchWorkspaceGlobs(gc.SchemaGlobs, strings.TrimPrefix(s.File, prefix)) {
+				gc.Schemas = append(gc.Schemas, s.File)
+			}
+		}
+		if gc.ResolverDir == "" {
+			continue
+		}
+		gc.Resolvers = map[string]string{}
+		for _, s := range gc.Schemas {
+			var res string
+			if gc.ResolverLayout == "follow-schema" {
+				base := path.Base(s)
+				res = path.Join(prefix+gc.ResolverDir, strings.TrimSuffix(base, path.Ext(base))+".resolvers.go")
+			} else {
+				res = path.Join(prefix+gc.ResolverDir, "resolver.go")
+				if !pathSet[res] {
+					res = path.Join(prefix+gc.ResolverDir, "schema.resolvers.go")
+				}
+			}
+			if !pathSet[res] {
+				res += " (missing)"
+			}
+			gc.Resolvers[s] = res
+		}
+	}
+	sort.Slice(cfgs, func(i, j int) bool { return cfgs[i].File < cfgs[j].File })
+}
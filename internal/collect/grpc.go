@@ -0,0 +1,198 @@
+package collect
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+)
+
+// GRPCImpl liga um service proto ao tipo Go que o implementa.
+type GRPCImpl struct {
+	Service string       `json:"service"`
+	Type    string       `json:"type"`  // Tipo
+	Where   string       `json:"where"` // declaração do tipo (arquivo:linha)
+	Embeds  bool         `json:"embeds_unimplemented,omitempty"`
+	Methods []GRPCMethod `json:"methods"`
+}
+
+// GRPCMethod é um RPC e o método Go que o atende.
+type GRPCMethod struct {
+	RPC           string `json:"rpc"`
+	Where         string `json:"where,omitempty"`
+	Unimplemented bool   `json:"unimplemented,omitempty"` // cai no stub Unimplemented<Svc>Server
+}
+
+// goServerFacts: structs (com campos embutidos) e métodos declarados num arquivo.
+type goServerFacts struct {
+	Dir     string
+	Types   map[string]goStructDecl
+	Methods map[string]map[string]string // tipo -> método -> arquivo:linha
+}
+
+type goStructDecl struct {
+	Where  string
+	Embeds []goEmbed
+}
+
+// goEmbed é um tipo embutido numa struct: nome base ("UnimplementedGreeterServer"),
+// qualificador ("healthv1"; "" = mesmo pacote da struct) e os imports do arquivo.
+type goEmbed struct {
+	Name      string
+	Qualifier string
+	imports   map[string]string // import path -> nome local
+}
+
+// serverFactsFromSource coleta structs e métodos de arquivos escritos à mão
+// (ignora testes, *.pb.go / *_grpc.pb.go e arquivos "Code generated").
+func serverFactsFromSource(gs *goSource) goServerFacts {
+	facts := goServerFacts{Dir: gs.Dir, Types: map[string]goStructDecl{}, Methods: map[string]map[string]string{}}
+	if gs.IsTest() || strings.HasSuffix(gs.Rel, ".pb.go") || gs.IsGenerated() {
+		return facts
+	}
+	for _, d := range gs.File.Decls {
+		switch x := d.(type) {
+		case *ast.GenDecl:
+			for _, spec := range x.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				decl := goStructDecl{Where: fmt.Sprintf("%s:%d", gs.Rel, gs.Line(ts.Pos()))}
+				for _, f := range st.Fields.List {
+					if len(f.Names) == 0 {
+						t := strings.TrimPrefix(exprString(f.Type), "*")
+						e := goEmbed{Name: t}
+						if pkg, name, ok := strings.Cut(t, "."); ok {
+							e.Name, e.Qualifier, e.imports = name, pkg, gs.Imports
+						}
+						decl.Embeds = append(decl.Embeds, e)
+					}
+				}
+				facts.Types[ts.Name.Name] = decl
+			}
+		case *ast.FuncDecl:
+			if x.Recv == nil || len(x.Recv.List) == 0 {
+				continue
+			}
+			recv, _ := receiverName(x.Recv.List[0].Type)
+			recv = strings.TrimPrefix(recv, "*")
+			if recv == "" {
+				continue
+			}
+			if facts.Methods[recv] == nil {
+				facts.Methods[recv] = map[string]string{}
+			}
+			facts.Methods[recv][x.Name.Name] = fmt.Sprintf("%s:%d", gs.Rel, gs.Line(x.Pos()))
+		}
+	}
+	return facts
+}
+
+// linkGRPCImpls procura, para cada service, tipos que embutem
+// Unimplemented<Svc>Server/Unsafe<Svc>Server do pacote gerado para o .proto
+// (go_package) ou cujo conjunto de métodos cobre todos os RPCs; RPCs sem
+// método próprio ficam marcados como stub.
+func linkGRPCImpls(protos []ProtoInfo, facts []goServerFacts, ix *goPackageIndex) {
+	type goType struct {
+		dir, name string
+		decl      goStructDecl
+		methods   map[string]string
+	}
+	byKey := map[string]*goType{}
+	for _, f := range facts {
+		for name, decl := range f.Types {
+			t := byKey[f.Dir+"."+name]
+			if t == nil {
+				t = &goType{dir: f.Dir, name: name, methods: map[string]string{}}
+				byKey[f.Dir+"."+name] = t
+			}
+			t.decl = decl
+		}
+		for recv, ms := range f.Methods {
+			t := byKey[f.Dir+"."+recv]
+			if t == nil {
+				t = &goType{dir: f.Dir, name: recv, methods: map[string]string{}}
+				byKey[f.Dir+"."+recv] = t
+			}
+			for m, where := range ms {
+				t.methods[m] = where
+			}
+		}
+	}
+	keys := make([]string, 0, len(byKey))
+	for k, t := range byKey {
+		if t.decl.Where != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for i := range protos {
+		p := &protos[i]
+		for _, svc := range dedupeSorted(p.Services) {
+			rpcs := p.serviceRPCs[svc]
+			found := false
+			for _, k := range keys {
+				t := byKey[k]
+				// Health/UserService em v1 e v2: o stub embutido precisa ser do pacote deste .proto
+				embeds, foreign := false, false
+				for _, e := range t.decl.Embeds {
+					if e.Name != "Unimplemented"+svc+"Server" && e.Name != "Unsafe"+svc+"Server" {
+						continue
+					}
+					if p.generatedIn(e, t.dir, ix) {
+						embeds = true
+					} else {
+						foreign = true
+					}
+				}
+				covers := len(rpcs) > 0 && !foreign
+				for _, rpc := range rpcs {
+					if _, ok := t.methods[rpc]; !ok {
+						covers = false
+						break
+					}
+				}
+				if !embeds && !covers {
+					continue
+				}
+				found = true
+				impl := GRPCImpl{Service: svc, Type: t.name, Where: t.decl.Where, Embeds: embeds}
+				for _, rpc := range rpcs {
+					where, ok := t.methods[rpc]
+					impl.Methods = append(impl.Methods, GRPCMethod{RPC: rpc, Where: where, Unimplemented: !ok})
+				}
+				p.Impls = append(p.Impls, impl)
+			}
+			if !found {
+				p.NoImpl = append(p.NoImpl, svc)
+			}
+		}
+	}
+}
+
+// generatedIn diz se o tipo embutido e (numa struct do diretório dir) vem do
+// pacote Go gerado para este .proto. Sem go_package não há como saber e vale.
+func (p *ProtoInfo) generatedIn(e goEmbed, dir string, ix *goPackageIndex) bool {
+	if p.goPackage == "" {
+		return true
+	}
+	genDir := ix.importDir(p.goPackage)
+	if e.Qualifier == "" {
+		return genDir != "" && dir == genDir
+	}
+	local, ok := e.imports[p.goPackage]
+	switch {
+	case !ok:
+		return false
+	case local == e.Qualifier || genDir == "":
+		return true // alias igual, ou pacote fora do repo (sem como conferir o nome)
+	}
+	// import sem alias de ".../health/v2": o nome local é o "package" do gerado
+	return ix.pkgs[genDir].Name == e.Qualifier
+}
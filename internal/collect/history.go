@@ -0,0 +1,290 @@
+package collect
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// History resume o histórico git local em uma janela de dias.
+type History struct {
+	Days         int           `json:"days"`
+	Commits      int           `json:"commits"`
+	Authors      int           `json:"authors"`
+	HotFiles     []Churn       `json:"hot_files,omitempty"`
+	HotDirs      []Churn       `json:"hot_dirs,omitempty"`
+	Areas        []HistoryArea `json:"areas,omitempty"`
+	StaleFiles   int           `json:"stale_files"` // sem commits há mais de um ano
+	StaleByArea  []AreaCount   `json:"stale_by_area,omitempty"`
+	StaleSample  []string      `json:"stale_sample,omitempty"`
+	ShallowClone bool          `json:"shallow_clone,omitempty"`
+}
+
+// Churn conta commits (e autores distintos) que tocaram um arquivo ou diretório.
+type Churn struct {
+	Path    string `json:"path"`
+	Commits int    `json:"commits"`
+	Authors int    `json:"authors"`
+}
+
+// HistoryArea é a atividade de um diretório de topo ("." = arquivos da raiz).
+type HistoryArea struct {
+	Area         string        `json:"area"`
+	Commits      int           `json:"commits"`
+	LastCommit   time.Time     `json:"last_commit"`
+	Latest       []CommitRef   `json:"latest"`
+	Contributors []Contributor `json:"contributors"`
+}
+
+// CommitRef identifica um commit.
+type CommitRef struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+	Subject string    `json:"subject"`
+}
+
+// Contributor é um autor e quantos commits fez na área.
+type Contributor struct {
+	Name    string `json:"name"`
+	Commits int    `json:"commits"`
+}
+
+// AreaCount é uma contagem por diretório de topo.
+type AreaCount struct {
+	Area  string `json:"area"`
+	Files int    `json:"files"`
+}
+
+type gitCommit struct {
+	CommitRef
+	Files []string
+}
+
+const staleAfter = 365 * 24 * time.Hour
+
+// collectHistory lê o histórico com "git log" (só o repositório local, sem rede).
+// Os caminhos saem relativos a root (--relative), então um root dentro do repo
+// também funciona. Com snap (-rev), o histórico parte do commit do snapshot e a
+// janela termina na data dele. Sem git ou fora de um repositório devolve nil.
+func collectHistory(ctx context.Context, root string, days int, paths []string, snap *files.GitFS) *History {
+	if days <= 0 {
+		return nil
+	}
+	now := time.Now()
+	head := "HEAD"
+	if snap != nil {
+		now, head = snap.Time, snap.Commit
+	}
+	since := now.Add(-time.Duration(days) * 24 * time.Hour)
+	// a janela de leitura cobre também o último ano, para achar arquivos parados
+	readSince := since
+	if s := now.Add(-staleAfter); s.Before(readSince) {
+		readSince = s
+	}
+	out, err := git(ctx, root, "log", "--no-merges", "--no-renames", "--relative", "--name-only",
+		"--since="+readSince.Format(time.RFC3339), "--format=%x00%h%x1f%an%x1f%ct%x1f%s", head, "--")
+	if err != nil {
+		return nil
+	}
+	commits := parseGitLog(out)
+
+	h := &History{Days: days}
+	if shallow, err := git(ctx, root, "rev-parse", "--is-shallow-repository"); err == nil {
+		h.ShallowClone = strings.TrimSpace(string(shallow)) == "true"
+	}
+
+	staleSince := now.Add(-staleAfter)
+	touchedYear := map[string]bool{}
+	fileChurn, dirChurn := map[string]*churnAcc{}, map[string]*churnAcc{}
+	bump := func(m map[string]*churnAcc, k, author string) {
+		c := m[k]
+		if c == nil {
+			c = &churnAcc{authors: map[string]bool{}}
+			m[k] = c
+		}
+		c.commits++
+		c.authors[author] = true
+	}
+	areas := map[string]*HistoryArea{}
+	areaAuthors := map[string]map[string]int{}
+	authors := map[string]bool{}
+	for _, c := range commits {
+		// com -history-days acima de um ano a janela lida vai além de staleAfter
+		if c.Date.After(staleSince) {
+			for _, f := range c.Files {
+				touchedYear[f] = true
+			}
+		}
+		// commits que só tocaram fora de root chegam sem arquivos (--relative)
+		if c.Date.Before(since) || len(c.Files) == 0 {
+			continue
+		}
+		h.Commits++
+		authors[c.Author] = true
+		seenDir, seenArea := map[string]bool{}, map[string]bool{}
+		for _, f := range c.Files {
+			bump(fileChurn, f, c.Author)
+			if d := path.Dir(f); !seenDir[d] {
+				seenDir[d] = true
+				bump(dirChurn, d, c.Author)
+			}
+			area := topLevelArea(f)
+			if seenArea[area] {
+				continue
+			}
+			seenArea[area] = true
+			a := areas[area]
+			if a == nil {
+				a = &HistoryArea{Area: area}
+				areas[area] = a
+				areaAuthors[area] = map[string]int{}
+			}
+			a.Commits++
+			areaAuthors[area][c.Author]++
+			// git log vem do mais novo para o mais antigo
+			if len(a.Latest) < 3 {
+				a.Latest = append(a.Latest, c.CommitRef)
+			}
+			if c.Date.After(a.LastCommit) {
+				a.LastCommit = c.Date
+			}
+		}
+	}
+	h.Authors = len(authors)
+	h.HotFiles = topChurn(fileChurn, 20)
+	h.HotDirs = topChurn(dirChurn, 15)
+	for name, a := range areas {
+		for who, n := range areaAuthors[name] {
+			a.Contributors = append(a.Contributors, Contributor{Name: who, Commits: n})
+		}
+		sort.Slice(a.Contributors, func(i, j int) bool {
+			if a.Contributors[i].Commits != a.Contributors[j].Commits {
+				return a.Contributors[i].Commits > a.Contributors[j].Commits
+			}
+			return a.Contributors[i].Name < a.Contributors[j].Name
+		})
+		if len(a.Contributors) > 5 {
+			a.Contributors = a.Contributors[:5]
+		}
+		h.Areas = append(h.Areas, *a)
+	}
+	sort.Slice(h.Areas, func(i, j int) bool {
+		if !h.Areas[i].LastCommit.Equal(h.Areas[j].LastCommit) {
+			return h.Areas[i].LastCommit.After(h.Areas[j].LastCommit)
+		}
+		return h.Areas[i].Area < h.Areas[j].Area
+	})
+
+	// Arquivo parado: versionado, varrido, e sem commit no último ano — só faz
+	// sentido se o histórico tem commits mais antigos que isso.
+	if old, err := git(ctx, root, "log", "-1", "--format=%h", "--before="+staleSince.Format(time.RFC3339), head, "--"); err == nil && len(bytes.TrimSpace(old)) > 0 {
+		tracked := map[string]bool{}
+		if snap != nil {
+			for _, p := range paths {
+				tracked[p] = true // o snapshot só tem arquivos versionados
+			}
+		} else if ls, err := git(ctx, root, "ls-files", "-z"); err == nil {
+			for _, f := range strings.Split(string(ls), "\x00") {
+				tracked[f] = true
+			}
+		}
+		byArea := map[string]int{}
+		for _, p := range paths {
+			if !tracked[p] || touchedYear[p] {
+				continue
+			}
+			h.StaleFiles++
+			byArea[topLevelArea(p)]++
+			if len(h.StaleSample) < 50 {
+				h.StaleSample = append(h.StaleSample, p)
+			}
+		}
+		for a, n := range byArea {
+			h.StaleByArea = append(h.StaleByArea, AreaCount{Area: a, Files: n})
+		}
+		sort.Slice(h.StaleByArea, func(i, j int) bool {
+			if h.StaleByArea[i].Files != h.StaleByArea[j].Files {
+				return h.StaleByArea[i].Files > h.StaleByArea[j].Files
+			}
+			return h.StaleByArea[i].Area < h.StaleByArea[j].Area
+		})
+	}
+	return h
+}
+
+// git roda um comando git em root e devolve a saída padrão.
+func git(ctx context.Context, root string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", root, "-c", "core.quotePath=false"}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// parseGitLog lê a saída de --format=%x00hash%x1fautor%x1fdata%x1fassunto --name-only.
+func parseGitLog(out []byte) []gitCommit {
+	var commits []gitCommit
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.HasPrefix(line, "\x00") {
+			parts := strings.SplitN(line[1:], "\x1f", 4)
+			if len(parts) < 4 {
+				continue
+			}
+			ts, _ := strconv.ParseInt(parts[2], 10, 64)
+			commits = append(commits, gitCommit{CommitRef: CommitRef{
+				Hash: parts[0], Author: parts[1], Date: time.Unix(ts, 0).UTC(), Subject: parts[3],
+			}})
+			continue
+		}
+		if line = strings.TrimSpace(line); line != "" && len(commits) > 0 {
+			c := &commits[len(commits)-1]
+			c.Files = append(c.Files, line)
+		}
+	}
+	return commits
+}
+
+func topLevelArea(p string) string {
+	if i := strings.IndexByte(p, '/'); i >= 0 {
+		return p[:i]
+	}
+	return "."
+}
+
+type churnAcc struct {
+	commits int
+	authors map[string]bool
+}
+
+func topChurn(m map[string]*churnAcc, n int) []Churn {
+	out := make([]Churn, 0, len(m))
+	for k, v := range m {
+		out = append(out, Churn{Path: k, Commits: v.commits, Authors: len(v.authors)})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Commits != out[j].Commits {
+			return out[i].Commits > out[j].Commits
+		}
+		return out[i].Path < out[j].Path
+	})
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
@@ -0,0 +1,329 @@
+package collect
+
+import (
+	"encoding/json"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// JSPackage descreve um package.json (pacote ou raiz de workspace npm/pnpm/yarn).
+type JSPackage struct {
+	Dir             string            `json:"dir"`
+	Name            string            `json:"name,omitempty"`
+	Version         string            `json:"version,omitempty"`
+	Private         bool              `json:"private,omitempty"`
+	Manager         string            `json:"manager,omitempty"`    // npm | pnpm | yarn | bun
+	Workspaces      []string          `json:"workspaces,omitempty"` // globs declarados (package.json ou pnpm-workspace.yaml)
+	Members         []string          `json:"members,omitempty"`    // pacotes do workspace resolvidos pelos globs
+	Dependencies    map[string]string `json:"dependencies,omitempty"`
+	DevDependencies map[string]string `json:"dev_dependencies,omitempty"`
+	PeerDeps        map[string]string `json:"peer_dependencies,omitempty"`
+	Engines         map[string]string `json:"engines,omitempty"`
+	Bin             map[string]string `json:"bin,omitempty"`
+	Exports         []string          `json:"exports,omitempty"` // subpaths de "exports"
+	Frameworks      []string          `json:"frameworks,omitempty"`
+	TSPaths         map[string]string `json:"ts_paths,omitempty"` // aliases de tsconfig compilerOptions.paths
+	Internal        []string          `json:"internal,omitempty"` // pacotes do repo dos quais depende
+	Dependents      []string          `json:"dependents,omitempty"`
+
+	pnpmWorkspace bool // fragmento vindo de pnpm-workspace.yaml
+}
+
+// jsFrameworks mapeia dependências marcantes para o framework exibido.
+var jsFrameworks = []struct{ dep, name string }{
+	{"next", "Next.js"},
+	{"nuxt", "Nuxt"},
+	{"@remix-run/react", "Remix"},
+	{"@sveltejs/kit", "SvelteKit"},
+	{"astro", "Astro"},
+	{"vite", "Vite"},
+	{"@nestjs/core", "NestJS"},
+	{"@angular/core", "Angular"},
+	{"react", "React"},
+	{"vue", "Vue"},
+	{"svelte", "Svelte"},
+	{"express", "Express"},
+	{"fastify", "Fastify"},
+	{"electron", "Electron"},
+}
+
+// jsManifestKind identifica package.json e pnpm-workspace.yaml.
+func jsManifestKind(lower string) string {
+	switch path.Base(lower) {
+	case "package.json":
+		return "package.json"
+	case "pnpm-workspace.yaml", "pnpm-workspace.yml":
+		return "pnpm-workspace"
+	}
+	return ""
+}
+
+// parseJSManifest lê um package.json (ou os globs de um pnpm-workspace.yaml,
+// devolvidos como pacote só com Workspaces, mesclado depois do Walk).
+func parseJSManifest(source *files.Source, full, rel, kind string, maxBytes int64) (*JSPackage, error) {
+	head, err := source.ReadHead(full, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	jp := &JSPackage{Dir: path.Dir(rel)}
+	if kind == "pnpm-workspace" {
+		var doc struct {
+			Packages []string `yaml:"packages"`
+		}
+		if err := yaml.Unmarshal([]byte(head), &doc); err != nil {
+			return nil, err
+		}
+		jp.Manager = "pnpm"
+		jp.Workspaces = doc.Packages
+		jp.pnpmWorkspace = true
+		return jp, nil
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(head), &doc); err != nil {
+		return nil, err
+	}
+	jp.Name = settingString(doc["name"])
+	jp.Version = settingString(doc["version"])
+	jp.Private, _ = doc["private"].(bool)
+	if pm := settingString(doc["packageManager"]); pm != "" {
+		jp.Manager, _, _ = strings.Cut(pm, "@")
+	}
+	// "workspaces": [...] (npm/yarn) ou {"packages": [...]} (yarn classic)
+	jp.Workspaces = toStrings(firstNonNil(getPath(doc, "workspaces.packages"), doc["workspaces"]))
+	jp.Dependencies = stringMap(doc["dependencies"])
+	jp.DevDependencies = stringMap(doc["devDependencies"])
+	jp.PeerDeps = stringMap(doc["peerDependencies"])
+	jp.Engines = stringMap(doc["engines"])
+
+	switch b := doc["bin"].(type) {
+	case string:
+		name := jp.Name
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			name = name[i+1:] // @scope/cli -> cli
+		}
+		jp.Bin = map[string]string{name: b}
+	case map[string]any:
+		jp.Bin = stringMap(b)
+	}
+
+	switch e := doc["exports"].(type) {
+	case string:
+		jp.Exports = []string{"."}
+	case map[string]any:
+		for _, k := range sortedKeys(e) {
+			if strings.HasPrefix(k, ".") {
+				jp.Exports = append(jp.Exports, k)
+			}
+		}
+		if len(jp.Exports) == 0 {
+			jp.Exports = []string{"."} // só condições (import/require/types)
+		}
+	}
+
+	for _, fw := range jsFrameworks {
+		_, inDeps := jp.Dependencies[fw.dep]
+		_, inDev := jp.DevDependencies[fw.dep]
+		if inDeps || inDev {
+			jp.Frameworks = append(jp.Frameworks, fw.name)
+		}
+	}
+	return jp, nil
+}
+
+// consolidateJSPackages mescla pnpm-workspace.yaml nos package.json do mesmo
+// diretório, resolve membros de workspaces, gerenciador (lockfile), aliases
+// do tsconfig e o grafo interno de dependências entre pacotes do repo.
+func consolidateJSPackages(source *files.Source, frags []JSPackage, root string, paths []string) []JSPackage {
+	pathSet := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		pathSet[p] = true
+	}
+	byDir := map[string]*JSPackage{}
+	var dirs []string
+	var workspaceOnly []JSPackage
+	for i := range frags {
+		if frags[i].pnpmWorkspace {
+			workspaceOnly = append(workspaceOnly, frags[i])
+			continue
+		}
+		cp := frags[i]
+		byDir[cp.Dir] = &cp
+		dirs = append(dirs, cp.Dir)
+	}
+	for _, w := range workspaceOnly {
+		jp, ok := byDir[w.Dir]
+		if !ok {
+			cp := w
+			jp = &cp
+			byDir[w.Dir] = jp
+			dirs = append(dirs, w.Dir)
+		}
+		jp.Manager = "pnpm"
+		jp.Workspaces = append(jp.Workspaces, w.Workspaces...)
+	}
+	sort.Strings(dirs)
+
+	byName := map[string]string{} // nome -> dir
+	for _, d := range dirs {
+		if n := byDir[d].Name; n != "" {
+			byName[n] = d
+		}
+	}
+
+	for _, d := range dirs {
+		jp := byDir[d]
+		prefix := ""
+		if d != "." {
+			prefix = d + "/"
+		}
+		if jp.Manager == "" {
+			switch {
+			case pathSet[prefix+"pnpm-lock.yaml"]:
+				jp.Manager = "pnpm"
+			case pathSet[prefix+"yarn.lock"]:
+				jp.Manager = "yarn"
+			case pathSet[prefix+"bun.lockb"] || pathSet[prefix+"bun.lock"]:
+				jp.Manager = "bun"
+			case pathSet[prefix+"package-lock.json"]:
+				jp.Manager = "npm"
+			}
+		}
+		// membros: package.json de subdiretórios que casam com os globs
+		if len(jp.Workspaces) > 0 {
+			for _, o := range dirs {
+				if o == d || !strings.HasPrefix(o, prefix) {
+					continue
+				}
+				if matchWorkspaceGlobs(jp.Workspaces, strings.TrimPrefix(o, prefix)) {
+					name := byDir[o].Name
+					if name == "" {
+						name = o
+					}
+					jp.Members = append(jp.Members, name)
+				}
+			}
+			jp.Members = dedupeSorted(jp.Members)
+		}
+		for _, cfg := range []string{"tsconfig.json", "jsconfig.json"} {
+			if pathSet[prefix+cfg] {
+				jp.TSPaths = tsconfigPaths(source, filepath.Join(root, prefix+cfg), 3)
+				break
+			}
+		}
+		// grafo interno: dependências cujo nome é um pacote do repo
+		var internal []string
+		for _, deps := range []map[string]string{jp.Dependencies, jp.DevDependencies, jp.PeerDeps} {
+			for name := range deps {
+				if od, ok := byName[name]; ok && od != d {
+					internal = append(internal, name)
+				}
+			}
+		}
+		jp.Internal = dedupeSorted(internal)
+	}
+
+	out := make([]JSPackage, 0, len(dirs))
+	for _, d := range dirs {
+		jp := byDir[d]
+		for _, dep := range jp.Internal {
+			target := byDir[byName[dep]]
+			target.Dependents = append(target.Dependents, displayJSName(jp))
+		}
+	}
+	for _, d := range dirs {
+		jp := byDir[d]
+		jp.Dependents = dedupeSorted(jp.Dependents)
+		out = append(out, *jp)
+	}
+	return out
+}
+
+func displayJSName(jp *JSPackage) string {
+	if jp.Name != "" {
+		return jp.Name
+	}
+	return jp.Dir
+}
+
+// matchWorkspaceGlobs aplica os globs de workspace (com "**" e negação "!")
+// a um diretório relativo à raiz do workspace.
+func matchWorkspaceGlobs(globs []string, dir string) bool {
+	matched := false
+	for _, g := range globs {
+		neg := strings.HasPrefix(g, "!")
+		g = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(g, "!"), "./"), "/")
+		if matchSegments(strings.Split(g, "/"), strings.Split(dir, "/")) {
+			matched = !neg
+		}
+	}
+	return matched
+}
+
+func matchSegments(pat, segs []string) bool {
+	if len(pat) == 0 {
+		return len(segs) == 0
+	}
+	if pat[0] == "**" {
+		for i := 0; i <= len(segs); i++ {
+			if matchSegments(pat[1:], segs[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segs) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pat[0], segs[0]); !ok {
+		return false
+	}
+	return matchSegments(pat[1:], segs[1:])
+}
+
+// tsconfigPaths lê compilerOptions.paths, seguindo "extends" relativo
+// (tsconfig.base.json na raiz do monorepo é o caso comum).
+func tsconfigPaths(source *files.Source, file string, depth int) map[string]string {
+	data, err := source.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	var doc map[string]any
+	if json.Unmarshal([]byte(stripJSONC(string(data))), &doc) != nil {
+		return nil
+	}
+	if p, ok := getPath(doc, "compilerOptions.paths").(map[string]any); ok && len(p) > 0 {
+		out := map[string]string{}
+		for k, v := range p {
+			out[k] = settingString(v)
+		}
+		return out
+	}
+	ext := settingString(doc["extends"])
+	if depth > 0 && strings.HasPrefix(ext, ".") {
+		if !strings.HasSuffix(ext, ".json") {
+			ext += ".json"
+		}
+		return tsconfigPaths(source, filepath.Join(filepath.Dir(file), ext), depth-1)
+	}
+	return nil
+}
+
+// stringMap converte um objeto JSON em map[string]string.
+func stringMap(v any) map[string]string {
+	m, ok := v.(map[string]any)
+	if !ok || len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(m))
+	for k, x := range m {
+		out[k] = settingString(x)
+	}
+	return out
+}
@@ -0,0 +1,29 @@
+package collect
+
+import "testing"
+
+func TestMatchWorkspaceGlobs(t *testing.T) {
+	tests := []struct {
+		globs []string
+		dir   string
+		want  bool
+	}{
+		{[]string{"packages/*"}, "packages/ui", true},
+		{[]string{"packages/*"}, "packages/ui/nested", false},
+		{[]string{"./packages/*/"}, "packages/ui", true},
+		{[]string{"apps/**"}, "apps/web/admin", true},
+		{[]string{"apps/**"}, "apps", true},
+		{[]string{"**/pkg-*"}, "libs/deep/pkg-a", true},
+		{[]string{"tools"}, "tools", true},
+		{[]string{"tools"}, "tools/x", false},
+		{[]string{"packages/*", "!packages/legacy"}, "packages/legacy", false},
+		{[]string{"!packages/legacy", "packages/*"}, "packages/legacy", true}, // a última regra vence
+		{[]string{"packages/*"}, "apps/web", false},
+		{nil, "packages/ui", false},
+	}
+	for _, tt := range tests {
+		if got := matchWorkspaceGlobs(tt.globs, tt.dir); got != tt.want {
+			t.Errorf("matchWorkspaceGlobs(%q, %q) = %v, want %v", tt.globs, tt.dir, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package collect
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// TestResults agrega relatórios de execução de testes (JUnit/xUnit XML e `go test -json`).
+type TestResults struct {
+	Reports  []string         `json:"reports"` // caminhos dos relatórios lidos
+	Suites   int              `json:"suites"`
+	Tests    int              `json:"tests"`
+	Passed   int              `json:"passed"`
+	Failed   int              `json:"failed"`
+	Errors   int              `json:"errors"`
+	Skipped  int              `json:"skipped"`
+	Seconds  float64          `json:"seconds"`           // soma dos tempos dos testcases
+	Slowest  []TestCaseResult `json:"slowest,omitempty"` // top 10 por duração
+	Failing  []TestCaseResult `json:"failing,omitempty"` // falhas + erros
+	GoFormat bool             `json:"go_format"`         // se algum relatório veio de go-junit-report/gotestsum/test2json
+}
+
+// TestCaseResult é um testcase individual de um relatório.
+type TestCaseResult struct {
+	Suite   string  `json:"suite"` // testsuite (em Go, o import path do pacote)
+	Name    string  `json:"name"`
+	Seconds float64 `json:"seconds"`
+	Status  string  `json:"status"`            // passed | failed | error | skipped
+	Message string  `json:"message,omitempty"` // primeira linha da falha/erro
+}
+
+const (
+	maxSlowestTests = 10
+	maxFailingTests = 50
+)
+
+// Estruturas mínimas para o formato JUnit (Ant/Maven Surefire, go-junit-report, gotestsum).
+type junitSuites struct {
+	Suites []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name       string          `xml:"name,attr"`
+	Cases      []junitCase     `xml:"testcase"`
+	Suites     []junitSuite    `xml:"testsuite"` // alguns geradores aninham suites
+	Properties []junitProperty `xml:"properties>property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitOutcome `xml:"failure"`
+	Error     *junitOutcome `xml:"error"`
+	Skipped   *junitOutcome `xml:"skipped"`
+}
+
+type junitOutcome struct {
+	Message string `xml:"message,attr"`
+	Body    string `xml:",chardata"`
+}
+
+// addJUnitXML lê um relatório JUnit/xUnit (raiz <testsuites> ou <testsuite>).
+// Forma resiliente: arquivos inválidos são ignorados.
+func (tr *TestResults) addJUnitXML(source *files.Source, path string, cases *[]TestCaseResult) bool {
+	data, err := source.ReadFile(path)
+	if err != nil || len(data) == 0 {
+		return false
+	}
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	var start xml.StartElement
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		if se, ok := tok.(xml.StartElement); ok {
+			start = se
+			break
+		}
+	}
+	var suites []junitSuite
+	switch start.Name.Local {
+	case "testsuites":
+		var root junitSuites
+		if dec.DecodeElement(&root, &start) != nil {
+			return false
+		}
+		suites = root.Suites
+	case "testsuite":
+		var s junitSuite
+		if dec.DecodeElement(&s, &start) != nil {
+			return false
+		}
+		suites = []junitSuite{s}
+	default:
+		return false
+	}
+
+	var walk func(s junitSuite)
+	walk = func(s junitSuite) {
+		if len(s.Cases) > 0 {
+			tr.Suites++
+		}
+		for _, p := range s.Properties {
+			// go-junit-report e gotestsum gravam go.version nas properties
+			if p.Name == "go.version" {
+				tr.GoFormat = true
+			}
+		}
+		for _, c := range s.Cases {
+			r := TestCaseResult{Suite: s.Name, Name: c.Name, Seconds: parseSeconds(c.Time), Status: "passed"}
+			if r.Suite == "" {
+				r.Suite = c.Classname
+			}
+			switch {
+			case c.Failure != nil:
+				r.Status, r.Message = "failed", outcomeMessage(c.Failure)
+			case c.Error != nil:
+				r.Status, r.Message = "error", outcomeMessage(c.Error)
+			case c.Skipped != nil:
+				r.Status = "skipped"
+			}
+			*cases = append(*cases, r)
+		}
+		for _, child := range s.Suites {
+			walk(child)
+		}
+	}
+	for _, s := range suites {
+		walk(s)
+	}
+	return true
+}
+
+// addGoTestJSON lê a saída de `go test -json` (test2json), como a gravada por
+// `gotestsum --jsonfile`. Cada teste vale pelo seu evento final (pass/fail/skip).
+func (tr *TestResults) addGoTestJSON(source *files.Source, path string, cases *[]TestCaseResult) bool {
+	f, err := source.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = f.Close() }()
+
+	type event struct {
+		Action  string  `json:"Action"`
+		Package string  `json:"Package"`
+		Test    string  `json:"Test"`
+		Elapsed float64 `json:"Elapsed"`
+		Output  string  `json:"Output"`
+	}
+	pkgs := map[string]bool{}
+	output := map[string]string{} // primeira linha "relevante" de saída por teste
+	found := false
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		var ev event
+		if json.Unmarshal(sc.Bytes(), &ev) != nil || ev.Action == "" {
+			continue
+		}
+		found = true
+		if ev.Test == "" {
+			continue
+		}
+		key := ev.Package + "\x00" + ev.Test
+		switch ev.Action {
+		case "output":
+			line := strings.TrimSpace(ev.Output)
+			if output[key] == "" && line != "" && !strings.HasPrefix(line, "=== ") && !strings.HasPrefix(line, "--- ") {
+				output[key] = line
+			}
+		case "pass", "fail", "skip":
+			pkgs[ev.Package] = true
+			r := TestCaseResult{Suite: ev.Package, Name: ev.Test, Seconds: ev.Elapsed, Status: "passed"}
+			switch ev.Action {
+			case "fail":
+				r.Status, r.Message = "failed", limitLine(output[key], 200)
+			case "skip":
+				r.Status = "skipped"
+			}
+			*cases = append(*cases, r)
+		}
+	}
+	if !found {
+		return false
+	}
+	tr.Suites += len(pkgs)
+	tr.GoFormat = true
+	return true
+}
+
+// consolidateTestResults lê os relatórios listados em tr.Reports e agrega totais,
+// testes mais lentos e falhas.
+func consolidateTestResults(source *files.Source, tr *TestResults, root string) {
+	var cases []TestCaseResult
+	for _, rel := range tr.Reports {
+		full := filepath.Join(root, rel)
+		low := strings.ToLower(rel)
+		if strings.HasSuffix(low, ".xml") {
+			tr.addJUnitXML(source, full, &cases)
+		} else {
+			tr.addGoTestJSON(source, full, &cases)
+		}
+	}
+	for _, c := range cases {
+		tr.Tests++
+		tr.Seconds += c.Seconds
+		switch c.Status {
+		case "passed":
+			tr.Passed++
+		case "failed":
+			tr.Failed++
+			if len(tr.Failing) < maxFailingTests {
+				tr.Failing = append(tr.Failing, c)
+			}
+		case "error":
+			tr.Errors++
+			if len(tr.Failing) < maxFailingTests {
+				tr.Failing = append(tr.Failing, c)
+			}
+		case "skipped":
+			tr.Skipped++
+		}
+	}
+	slow := append([]TestCaseResult(nil), cases...)
+	sort.SliceStable(slow, func(i, j int) bool { return slow[i].Seconds > slow[j].Seconds })
+	for _, c := range slow {
+		if len(tr.Slowest) >= maxSlowestTests || c.Seconds <= 0 {
+			break
+		}
+		tr.Slowest = append(tr.Slowest, c)
+	}
+}
+
+// isGoTestJSONReport reconhece arquivos de `go test -json` pelo nome
+// (gotestsum --jsonfile, test2json, go-test*.json).
+func isGoTestJSONReport(lower string) bool {
+	base := lower
+	if i := strings.LastIndex(base, "/"); i >= 0 {
+		base = base[i+1:]
+	}
+	if !strings.HasSuffix(base, ".json") && !strings.HasSuffix(base, ".jsonl") {
+		return false
+	}
+	return strings.Contains(base, "gotestsum") || strings.Contains(base, "test2json") || strings.HasPrefix(base, "go-test")
+}
+
+// isJUnitReport reconhece relatórios JUnit/xUnit pelo nome (junit*.xml,
+// TEST-*.xml do Surefire, xunit*.xml, test-results*.xml, report.xml de go-junit-report).
+func isJUnitReport(lower string) bool {
+	if !strings.HasSuffix(lower, ".xml") {
+		return false
+	}
+	base := lower
+	if i := strings.LastIndex(base, "/"); i >= 0 {
+		base = base[i+1:]
+	}
+	return strings.Contains(base, "junit") || strings.Contains(base, "xunit") ||
+		strings.HasPrefix(base, "test-") || strings.Contains(base, "test-result") ||
+		strings.Contains(base, "cucumber") || base == "report.xml" || base == "tests.xml"
+}
+
+// outcomeMessage prefere o atributo message; go-junit-report grava só "Failed"
+// nele e a saída real do teste no corpo, então mensagens genéricas caem no corpo.
+func outcomeMessage(o *junitOutcome) string {
+	m := strings.TrimSpace(o.Message)
+	if m != "" && !strings.EqualFold(m, "failed") && !strings.EqualFold(m, "error") {
+		return limitLine(m, 200)
+	}
+	for _, ln := range strings.Split(o.Body, "\n") {
+		if ln = strings.TrimSpace(ln); ln != "" {
+			return limitLine(ln, 200)
+		}
+	}
+	return limitLine(m, 200)
+}
+
+func parseSeconds(s string) float64 {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	if s == "" {
+		return 0
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return v
+}
+
+// limitLine corta a primeira linha de s em até n bytes, sem partir um caractere UTF-8.
+func limitLine(s string, n int) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.TrimSpace(s)
+	if len(s) > n {
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		return s[:n] + "…"
+	}
+	return s
+}
@@ -0,0 +1,146 @@
+package collect
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"unicode/utf8"
+)
+
+func writeTemp(t *testing.T, name, content string) string {
+	t.Helper()
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestAddJUnitXML(t *testing.T) {
+	tests := []struct {
+		name   string
+		xml    string
+		ok     bool
+		suites int
+		goFmt  bool
+		cases  []TestCaseResult
+	}{
+		{
+			name: "testsuites com go.version",
+			xml: `<?xml version="1.0"?>
+<testsuites>
+  <testsuite name="example.com/m/pkg">
+    <properties><property name="go.version" value="go1.23"/></properties>
+    <testcase name="TestOK" time="0.5"/>
+    <testcase name="TestBad" time="1,200.0"><failure message="boom">stack</failure></testcase>
+    <testcase name="TestSkip"><skipped/></testcase>
+  </testsuite>
+</testsuites>`,
+			ok: true, suites: 1, goFmt: true,
+			cases: []TestCaseResult{
+				{Suite: "example.com/m/pkg", Name: "TestOK", Seconds: 0.5, Status: "passed"},
+				{Suite: "example.com/m/pkg", Name: "TestBad", Seconds: 1200, Status: "failed", Message: "boom"},
+				{Suite: "example.com/m/pkg", Name: "TestSkip", Status: "skipped"},
+			},
+		},
+		{
+			name: "testsuite raiz com suite aninhada e classname",
+			xml: `<testsuite name="outer">
+  <testsuite>
+    <testcase classname="com.acme.FooTest" name="bar"><error>NPE
+at line 1</error></testcase>
+  </testsuite>
+</testsuite>`,
+			ok: true, suites: 1,
+			cases: []TestCaseResult{
+				{Suite: "com.acme.FooTest", Name: "bar", Status: "error", Message: "NPE"},
+			},
+		},
+		{name: "outro xml", xml: `<project><testcase name="x"/></project>`},
+		{name: "xml inválido", xml: `<testsuites><testsuite>`},
+		{name: "vazio", xml: ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tr TestResults
+			var cases []TestCaseResult
+			ok := tr.addJUnitXML(nil, writeTemp(t, "report.xml", tt.xml), &cases)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if tr.Suites != tt.suites || tr.GoFormat != tt.goFmt {
+				t.Errorf("suites=%d goFormat=%v, want %d %v", tr.Suites, tr.GoFormat, tt.suites, tt.goFmt)
+			}
+			if !reflect.DeepEqual(cases, tt.cases) {
+				t.Errorf("cases = %+v\nwant %+v", cases, tt.cases)
+			}
+		})
+	}
+}
+
+func TestAddGoTestJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		json   string
+		ok     bool
+		suites int
+		cases  []TestCaseResult
+	}{
+		{
+			name: "pass, fail e skip",
+			json: `{"Action":"run","Package":"m/a","Test":"TestA"}
+{"Action":"output","Package":"m/a","Test":"TestA","Output":"=== RUN   TestA\n"}
+{"Action":"output","Package":"m/a","Test":"TestA","Output":"    a_test.go:9: esperado 1\n"}
+{"Action":"fail","Package":"m/a","Test":"TestA","Elapsed":0.25}
+{"Action":"pass","Package":"m/a","Test":"TestB","Elapsed":1}
+{"Action":"skip","Package":"m/b","Test":"TestC"}
+{"Action":"pass","Package":"m/b","Elapsed":2}
+`,
+			ok: true, suites: 2,
+			cases: []TestCaseResult{
+				{Suite: "m/a", Name: "TestA", Seconds: 0.25, Status: "failed", Message: "a_test.go:9: esperado 1"},
+				{Suite: "m/a", Name: "TestB", Seconds: 1, Status: "passed"},
+				{Suite: "m/b", Name: "TestC", Status: "skipped"},
+			},
+		},
+		{name: "sem eventos", json: "not json\n{}\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var tr TestResults
+			var cases []TestCaseResult
+			ok := tr.addGoTestJSON(nil, writeTemp(t, "report.json", tt.json), &cases)
+			if ok != tt.ok {
+				t.Fatalf("ok = %v, want %v", ok, tt.ok)
+			}
+			if ok && (tr.Suites != tt.suites || !tr.GoFormat) {
+				t.Errorf("suites=%d goFormat=%v, want %d true", tr.Suites, tr.GoFormat, tt.suites)
+			}
+			if !reflect.DeepEqual(cases, tt.cases) {
+				t.Errorf("cases = %+v\nwant %+v", cases, tt.cases)
+			}
+		})
+	}
+}
+
+func TestLimitLine(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"curta", 10, "curta"},
+		{"  primeira\nsegunda", 20, "primeira"},
+		{"abcdef", 3, "abc…"},
+		{"ação", 2, "a…"}, // "ç" ocupa os bytes 1-2
+		{"ação", 3, "aç…"},
+		{"日本語", 4, "日…"},
+	}
+	for _, tt := range tests {
+		got := limitLine(tt.in, tt.n)
+		if got != tt.want || !utf8.ValidString(got) {
+			t.Errorf("limitLine(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package collect
+
+import (
+	"encoding/xml"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// JVMProject descreve um build Maven (pom.xml) ou Gradle (settings/build.gradle[.kts]);
+// cumpre para a JVM o papel que GoModule cumpre para Go.
+type JVMProject struct {
+	Dir          string   `json:"dir"`
+	Files        []string `json:"files"`
+	Tool         string   `json:"tool"`               // maven | gradle
+	Language     string   `json:"language,omitempty"` // java | kotlin | groovy
+	Name         string   `json:"name,omitempty"`     // groupId:artifactId (Maven) ou rootProject.name/dir (Gradle)
+	Version      string   `json:"version,omitempty"`
+	Packaging    string   `json:"packaging,omitempty"`
+	Parent       string   `json:"parent,omitempty"`
+	Modules      []string `json:"modules,omitempty"` // <modules> ou include(...) do settings.gradle
+	Plugins      []string `json:"plugins,omitempty"`
+	Dependencies []string `json:"dependencies,omitempty"` // "g:a:v (scope)" ou "configuration g:a:v"
+	Internal     []string `json:"internal,omitempty"`     // módulos/projetos do próprio repo dos quais depende
+}
+
+type pomDep struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Scope      string `xml:"scope"`
+}
+
+type pomXML struct {
+	Parent struct {
+		GroupID    string `xml:"groupId"`
+		ArtifactID string `xml:"artifactId"`
+		Version    string `xml:"version"`
+	} `xml:"parent"`
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version"`
+	Packaging  string `xml:"packaging"`
+	Properties struct {
+		Entries []struct {
+			XMLName xml.Name
+			Value   string `xml:",chardata"`
+		} `xml:",any"`
+	} `xml:"properties"`
+	Modules      []string `xml:"modules>module"`
+	Dependencies []pomDep `xml:"dependencies>dependency"`
+	Plugins      []pomDep `xml:"build>plugins>plugin"`
+}
+
+var pomPropRe = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// jvmBuildKind identifica pom.xml e scripts Gradle.
+func jvmBuildKind(lower string) string {
+	switch path.Base(lower) {
+	case "pom.xml":
+		return "maven"
+	case "settings.gradle", "settings.gradle.kts":
+		return "gradle-settings"
+	case "build.gradle", "build.gradle.kts":
+		return "gradle"
+	}
+	return ""
+}
+
+// parseJVMBuild lê um pom.xml ou script Gradle e devolve o fragmento do diretório.
+func parseJVMBuild(source *files.Source, full, rel, kind string, maxBytes int64) (*JVMProject, error) {
+	head, err := source.ReadHead(full, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	jp := &JVMProject{Dir: path.Dir(rel), Files: []string{rel}}
+	if kind == "maven" {
+		return jp, parsePom(jp, head)
+	}
+	jp.Tool = "gradle"
+	jp.Language = "groovy"
+	if strings.HasSuffix(rel, ".kts") {
+		jp.Language = "kotlin"
+	}
+	src := stripGradleComments(head)
+	if kind == "gradle-settings" {
+		if m := gradleRootNameRe.FindStringSubmatch(src); m != nil {
+			jp.Name = m[1]
+		}
+		for _, m := range gradleIncludeRe.FindAllStringSubmatch(src, -1) {
+			for _, q := range gradleQuotedRe.FindAllStringSubmatch(m[1], -1) {
+				jp.Modules = append(jp.Modules, q[1])
+			}
+		}
+		return jp, nil
+	}
+	parseGradleBuild(jp, src)
+	return jp, nil
+}
+
+func parsePom(jp *JVMProject, content string) error {
+	var pom pomXML
+	if err := xml.Unmarshal([]byte(content), &pom); err != nil {
+		return err
+	}
+	jp.Tool = "maven"
+	jp.Language = "java"
+	props := map[string]string{}
+	for _, e := range pom.Properties.Entries {
+		props[e.XMLName.Local] = strings.TrimSpace(e.Value)
+	}
+	group := firstNonEmpty(pom.GroupID, pom.Parent.GroupID)
+	version := firstNonEmpty(pom.Version, pom.Parent.Version)
+	props["project.groupId"], props["project.version"] = group, version
+	props["project.artifactId"] = pom.ArtifactID
+	resolve := func(s string) string {
+		return pomPropRe.ReplaceAllStringFunc(strings.TrimSpace(s), func(m string) string {
+			if v, ok := props[m[2:len(m)-1]]; ok {
+				return v
+			}
+			return m
+		})
+	}
+
+	jp.Name = resolve(group) + ":" + resolve(pom.ArtifactID)
+	jp.Version = resolve(version)
+	jp.Packaging = firstNonEmpty(pom.Packaging, "jar")
+	if pom.Parent.ArtifactID != "" {
+		jp.Parent = pom.Parent.GroupID + ":" + pom.Parent.ArtifactID + ":" + resolve(pom.Parent.Version)
+	}
+	jp.Modules = pom.Modules
+	for _, d := range pom.Dependencies {
+		coord := resolve(d.GroupID) + ":" + resolve(d.ArtifactID)
+		if v := resolve(d.Version); v != "" {
+			coord += ":" + v
+		}
+		if d.Scope != "" && d.Scope != "compile" {
+			coord += " (" + d.Scope + ")"
+		}
+		jp.Dependencies = append(jp.Dependencies, coord)
+	}
+	for _, p := range pom.Plugins {
+		jp.Plugins = append(jp.Plugins, resolve(p.ArtifactID))
+		if p.ArtifactID == "kotlin-maven-plugin" {
+			jp.Language = "kotlin"
+		}
+	}
+	return nil
+}
+
+var (
+	gradleRootNameRe   = regexp.MustCompile(`rootProject\.name\s*=\s*["']([^"']+)["']`)
+	gradleIncludeRe    = regexp.MustCompile(`(?m)^\s*include\s*\(?([^\n)]*)`)
+	gradleQuotedRe     = regexp.MustCompile(`["']([^"']+)["']`)
+	gradlePluginIDRe   = regexp.MustCompile(`^\s*id\s*\(?\s*["']([^"']+)["']`)
+	gradleKotlinPlugRe = regexp.MustCompile(`^\s*kotlin\s*\(\s*["']([^"']+)["']`)
+	gradleBarePluginRe = regexp.MustCompile("^\\s*`?([a-z][\\w-]*)`?\\s*$")
+	gradleAliasRe      = regexp.MustCompile(`^\s*alias\s*\(\s*(libs\.plugins\.[\w.]+)`)
+	gradleApplyRe      = regexp.MustCompile(`apply\s*\(?\s*plugin\s*[:=]\s*["']([^"']+)["']`)
+	gradleVersionRe    = regexp.MustCompile(`(?m)^\s*version\s*=\s*["']([^"']+)["']`)
+	gradleDepRe        = regexp.MustCompile(`^\s*(\w+)\s*\(?\s*(?:platform\s*\(\s*)?(["'][^"']+["']|project\s*\(\s*(?:path\s*[:=]\s*)?["'][^"']+["']\s*\)|libs\.[\w.]+)`)
+)
+
+// parseGradleBuild extrai plugins e dependências por configuração de um
+// build.gradle(.kts) de forma heurística (blocos plugins { } e dependencies { }).
+func parseGradleBuild(jp *JVMProject, src string) {
+	if m := gradleVersionRe.FindStringSubmatch(src); m != nil {
+		jp.Version = m[1]
+	}
+	for _, m := range gradleApplyRe.FindAllStringSubmatch(src, -1) {
+		jp.Plugins = append(jp.Plugins, m[1])
+	}
+	for _, ln := range gradleBlockLines(src, "plugins") {
+		for _, re := range []*regexp.Regexp{gradlePluginIDRe, gradleAliasRe, gradleBarePluginRe} {
+			if m := re.FindStringSubmatch(ln); m != nil {
+				jp.Plugins = append(jp.Plugins, m[1])
+				break
+			}
+		}
+		if m := gradleKotlinPlugRe.FindStringSubmatch(ln); m != nil {
+			jp.Plugins = append(jp.Plugins, "kotlin-"+m[1])
+		}
+	}
+	for _, p := range jp.Plugins {
+		if strings.Contains(p, "kotlin") {
+			jp.Language = "kotlin"
+		}
+	}
+	for _, ln := range gradleBlockLines(src, "dependencies") {
+		m := gradleDepRe.FindStringSubmatch(ln)
+		if m == nil {
+			continue
+		}
+		conf, target := m[1], m[2]
+		if strings.HasPrefix(target, "project") {
+			if q := gradleQuotedRe.FindStringSubmatch(target); q != nil {
+				jp.Internal = append(jp.Internal, q[1])
+				target = "project(" + q[1] + ")"
+			}
+		}
+		jp.Dependencies = append(jp.Dependencies, conf+" "+strings.Trim(target, `"'`))
+	}
+}
+
+// gradleBlockLines devolve as linhas internas dos blocos "name {" (em qualquer
+// nível: subprojects { dependencies { } } também conta).
+func gradleBlockLines(src, name string) []string {
+	var out []string
+	depth, start := 0, -1
+	for _, ln := range strings.Split(src, "\n") {
+		t := strings.TrimSpace(ln)
+		delta := strings.Count(t, "{") - strings.Count(t, "}")
+		if start < 0 && strings.HasPrefix(t, name) && strings.HasPrefix(strings.TrimSpace(t[len(name):]), "{") {
+			start = depth
+			depth += delta
+			if depth <= start {
+				start = -1 // bloco de uma linha
+			}
+			continue
+		}
+		depth += delta
+		if start >= 0 {
+			if depth <= start {
+				start = -1
+				continue
+			}
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// stripGradleComments remove comentários // e /* */ (fora de strings simples).
+func stripGradleComments(src string) string {
+	var b strings.Builder
+	var quote byte
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		if quote != 0 {
+			b.WriteByte(c)
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch {
+		case c == '"' || c == '\'':
+			quote = c
+			b.WriteByte(c)
+		case c == '/' && i+1 < len(src) && src[i+1] == '/':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+			b.WriteByte('\n')
+		case c == '/' && i+1 < len(src) && src[i+1] == '*':
+			for i+1 < len(src) && !(src[i] == '*' && src[i+1] == '/') {
+				i++
+			}
+			i++
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// mergeJVMProjects junta fragmentos por diretório (settings + build do
+// Gradle) e resolve dependências internas entre módulos Maven do repo.
+func mergeJVMProjects(frags []JVMProject) []JVMProject {
+	sort.SliceStable(frags, func(i, j int) bool { return frags[i].Files[0] < frags[j].Files[0] })
+	byDir := map[string]*JVMProject{}
+	var dirs []string
+	for _, f := range frags {
+		jp, ok := byDir[f.Dir]
+		if !ok {
+			cp := f
+			byDir[f.Dir] = &cp
+			dirs = append(dirs, f.Dir)
+			continue
+		}
+		jp.Files = append(jp.Files, f.Files...)
+		jp.Name = firstNonEmpty(jp.Name, f.Name)
+		jp.Version = firstNonEmpty(jp.Version, f.Version)
+		if f.Language == "kotlin" {
+			jp.Language = "kotlin"
+		}
+		jp.Modules = append(jp.Modules, f.Modules...)
+		jp.Plugins = append(jp.Plugins, f.Plugins...)
+		jp.Dependencies = append(jp.Dependencies, f.Dependencies...)
+		jp.Internal = append(jp.Internal, f.Internal...)
+	}
+	sort.Strings(dirs)
+
+	artifacts := map[string]bool{}
+	for _, d := range dirs {
+		if jp := byDir[d]; jp.Tool == "maven" {
+			artifacts[jp.Name] = true
+		}
+	}
+	out := make([]JVMProject, 0, len(dirs))
+	for _, d := range dirs {
+		jp := byDir[d]
+		if jp.Name == "" {
+			jp.Name = path.Base(d)
+		}
+		for _, dep := range jp.Dependencies {
+			if jp.Tool != "maven" {
+				break
+			}
+			ga := strings.Fields(dep)[0]
+			if parts := strings.Split(ga, ":"); len(parts) >= 2 && artifacts[parts[0]+":"+parts[1]] {
+				jp.Internal = append(jp.Internal, parts[0]+":"+parts[1])
+			}
+		}
+		jp.Plugins = dedupeSorted(jp.Plugins)
+		jp.Internal = dedupeSorted(jp.Internal)
+		out = append(out, *jp)
+	}
+	return out
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,349 @@
+package collect
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// LanguageStats detalha as linhas de código escrito à mão por linguagem.
+type LanguageStats struct {
+	Languages []LanguageLOC `json:"languages"`
+	Total     LanguageLOC   `json:"total"`
+	Largest   []FileLOC     `json:"largest_files,omitempty"`
+}
+
+// LanguageLOC soma arquivos e linhas (código, comentário, em branco) de uma linguagem.
+type LanguageLOC struct {
+	Language string `json:"language"`
+	Files    int    `json:"files"`
+	Code     int    `json:"code"`
+	Comment  int    `json:"comment"`
+	Blank    int    `json:"blank"`
+}
+
+// FileLOC é a contagem de um arquivo.
+type FileLOC struct {
+	File     string `json:"file"`
+	Language string `json:"language"`
+	Lines    int    `json:"lines"`
+	Code     int    `json:"code"`
+	Comment  int    `json:"comment"`
+	Blank    int    `json:"blank"`
+}
+
+// langSyntax descreve a sintaxe de comentários de uma linguagem.
+type langSyntax struct {
+	Name  string
+	Line  []string
+	Block [][2]string
+}
+
+var (
+	cStyle    = [][2]string{{"/*", "*/"}}
+	htmlStyle = [][2]string{{"<!--", "-->"}}
+
+	languages = []langSyntax{
+		{Name: "Go", Line: []string{"//"}, Block: cStyle},
+		{Name: "Rust", Line: []string{"//"}, Block: cStyle},
+		{Name: "C", Line: []string{"//"}, Block: cStyle},
+		{Name: "C++", Line: []string{"//"}, Block: cStyle},
+		{Name: "C#", Line: []string{"//"}, Block: cStyle},
+		{Name: "Java", Line: []string{"//"}, Block: cStyle},
+		{Name: "Kotlin", Line: []string{"//"}, Block: cStyle},
+		{Name: "Scala", Line: []string{"//"}, Block: cStyle},
+		{Name: "Groovy", Line: []string{"//"}, Block: cStyle},
+		{Name: "Swift", Line: []string{"//"}, Block: cStyle},
+		{Name: "Dart", Line: []string{"//"}, Block: cStyle},
+		{Name: "JavaScript", Line: []string{"//"}, Block: cStyle},
+		{Name: "TypeScript", Line: []string{"//"}, Block: cStyle},
+		{Name: "Protobuf", Line: []string{"//"}, Block: cStyle},
+		{Name: "PHP", Line: []string{"//", "#"}, Block: cStyle},
+		{Name: "CSS", Block: cStyle},
+		{Name: "SCSS", Line: []string{"//"}, Block: cStyle},
+		{Name: "Less", Line: []string{"//"}, Block: cStyle},
+		{Name: "HCL", Line: []string{"#", "//"}, Block: cStyle},
+		{Name: "SQL", Line: []string{"--"}, Block: cStyle},
+		{Name: "Lua", Line: []string{"--"}, Block: [][2]string{{"--[[", "]]"}}},
+		{Name: "Haskell", Line: []string{"--"}, Block: [][2]string{{"{-", "-}"}}},
+		{Name: "Python", Line: []string{"#"}},
+		{Name: "Ruby", Line: []string{"#"}, Block: [][2]string{{"=begin", "=end"}}},
+		{Name: "Perl", Line: []string{"#"}},
+		{Name: "R", Line: []string{"#"}},
+		{Name: "Elixir", Line: []string{"#"}},
+		{Name: "Shell", Line: []string{"#"}},
+		{Name: "PowerShell", Line: []string{"#"}, Block: [][2]string{{"<#", "#>"}}},
+		{Name: "Makefile", Line: []string{"#"}},
+		{Name: "Dockerfile", Line: []string{"#"}},
+		{Name: "CMake", Line: []string{"#"}},
+		{Name: "Starlark", Line: []string{"#"}},
+		{Name: "YAML", Line: []string{"#"}},
+		{Name: "TOML", Line: []string{"#"}},
+		{Name: "INI", Line: []string{"#", ";"}},
+		{Name: "GraphQL", Line: []string{"#"}},
+		{Name: "HTML", Block: htmlStyle},
+		{Name: "XML", Block: htmlStyle},
+		{Name: "Vue", Line: []string{"//"}, Block: append([][2]string{{"<!--", "-->"}}, cStyle...)},
+		{Name: "Svelte", Line: []string{"//"}, Block: append([][2]string{{"<!--", "-->"}}, cStyle...)},
+		{Name: "Markdown", Block: htmlStyle},
+		{Name: "JSON"},
+	}
+
+	langByExt = map[string]string{
+		".go": "Go", ".rs": "Rust",
+		".c": "C", ".h": "C",
+		".cc": "C++", ".cpp": "C++", ".cxx": "C++", ".hpp": "C++", ".hh": "C++", ".hxx": "C++",
+		".cs": "C#", ".java": "Java", ".kt": "Kotlin", ".kts": "Kotlin", ".scala": "Scala",
+		".groovy": "Groovy", ".gradle": "Groovy", ".swift": "Swift", ".dart": "Dart",
+		".js": "JavaScript", ".mjs": "JavaScript", ".cjs": "JavaScript", ".jsx": "JavaScript",
+		".ts": "TypeScript", ".tsx": "TypeScript", ".mts": "TypeScript", ".cts": "TypeScript",
+		".proto": "Protobuf", ".php": "PHP",
+		".css": "CSS", ".scss": "SCSS", ".sass": "SCSS", ".less": "Less",
+		".tf": "HCL", ".tfvars": "HCL", ".hcl": "HCL",
+		".sql": "SQL", ".lua": "Lua", ".hs": "Haskell",
+		".py": "Python", ".pyi": "Python", ".rb": "Ruby", ".rake": "Ruby",
+		".pl": "Perl", ".pm": "Perl", ".r": "R", ".ex": "Elixir", ".exs": "Elixir",
+		".sh": "Shell", ".bash": "Shell", ".zsh": "Shell", ".ps1": "PowerShell",
+		".mk": "Makefile", ".dockerfile": "Dockerfile", ".cmake": "CMake", ".bzl": "Starlark", ".star": "Starlark",
+		".yaml": "YAML", ".yml": "YAML", ".toml": "TOML", ".ini": "INI", ".cfg": "INI",
+		".graphql": "GraphQL", ".graphqls": "GraphQL", ".gql": "GraphQL",
+		".html": "HTML", ".htm": "HTML", ".xml": "XML", ".vue": "Vue", ".svelte": "Svelte",
+		".md": "Markdown", ".markdown": "Markdown", ".json": "JSON",
+	}
+
+	langByName = map[string]string{
+		"makefile": "Makefile", "gnumakefile": "Makefile", "justfile": "Makefile",
+		"dockerfile": "Dockerfile", "containerfile": "Dockerfile",
+		"cmakelists.txt": "CMake", "jenkinsfile": "Groovy",
+		"gemfile": "Ruby", "rakefile": "Ruby", "vagrantfile": "Ruby", "podfile": "Ruby",
+		"build": "Starlark", "build.bazel": "Starlark", "workspace": "Starlark", "workspace.bazel": "Starlark",
+		".bashrc": "Shell", ".zshrc": "Shell", ".profile": "Shell",
+	}
+
+	langByInterpreter = map[string]string{
+		"sh": "Shell", "bash": "Shell", "zsh": "Shell", "dash": "Shell", "ksh": "Shell",
+		"python": "Python", "ruby": "Ruby", "perl": "Perl", "node": "JavaScript",
+		"deno": "TypeScript", "bun": "TypeScript", "ts-node": "TypeScript", "tsx": "TypeScript",
+		"lua": "Lua", "pwsh": "PowerShell", "rscript": "R", "elixir": "Elixir",
+	}
+)
+
+var syntaxByName = func() map[string]*langSyntax {
+	m := map[string]*langSyntax{}
+	for i := range languages {
+		m[languages[i].Name] = &languages[i]
+	}
+	return m
+}()
+
+// detectLanguage identifica a linguagem pela extensão, por nomes especiais
+// (Makefile, Dockerfile, BUILD...) ou, sem extensão conhecida, pelo shebang.
+func detectLanguage(source *files.Source, full, lower string) *langSyntax {
+	base := path.Base(lower)
+	if name, ok := langByName[base]; ok {
+		return syntaxByName[name]
+	}
+	if strings.HasPrefix(base, "dockerfile.") || strings.HasSuffix(base, ".dockerfile") {
+		return syntaxByName["Dockerfile"]
+	}
+	if name, ok := langByExt[path.Ext(base)]; ok {
+		return syntaxByName[name]
+	}
+	if path.Ext(base) != "" && !strings.HasPrefix(base, ".") {
+		return nil
+	}
+	head, err := readFirstLine(source, full)
+	if err != nil {
+		return nil
+	}
+	if name := shebangLanguage(head); name != "" {
+		return syntaxByName[name]
+	}
+	return nil
+}
+
+func readFirstLine(source *files.Source, full string) (string, error) {
+	f, err := source.Open(full)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+	line, err := bufio.NewReader(io.LimitReader(f, 256)).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return line, nil
+}
+
+// shebangLanguage lê "#!/usr/bin/env -S python3 -u" -> Python.
+func shebangLanguage(line string) string {
+	if !strings.HasPrefix(line, "#!") {
+		return ""
+	}
+	words := strings.Fields(line[2:])
+	if len(words) == 0 {
+		return ""
+	}
+	interp := path.Base(words[0])
+	if interp == "env" {
+		interp = ""
+		for _, w := range words[1:] {
+			if !strings.HasPrefix(w, "-") && !strings.Contains(w, "=") {
+				interp = path.Base(w)
+				break
+			}
+		}
+	}
+	// python3.11 -> python
+	interp = strings.ToLower(strings.TrimRight(interp, "0123456789."))
+	return langByInterpreter[interp]
+}
+
+// countLines conta linhas de código, de comentário e em branco. É heurístico:
+// não entende strings, então um "/*" dentro de um literal abre um bloco.
+// Devolve ok=false se encontrar um byte NUL (arquivo binário).
+func countLines(r io.Reader, syn *langSyntax) (code, comment, blank int, ok bool) {
+	br := bufio.NewReaderSize(r, 64*1024)
+	inBlock := -1 // índice do bloco aberto em syn.Block
+	var line []byte
+	for {
+		chunk, isPrefix, err := br.ReadLine()
+		if err != nil {
+			break
+		}
+		line = append(line, chunk...)
+		if isPrefix {
+			continue
+		}
+		if bytes.IndexByte(line, 0) >= 0 {
+			return 0, 0, 0, false
+		}
+		hasCode, hasComment := scanLine(string(line), syn, &inBlock)
+		switch {
+		case hasCode:
+			code++
+		case hasComment:
+			comment++
+		default:
+			blank++
+		}
+		line = line[:0]
+	}
+	return code, comment, blank, true
+}
+
+// scanLine classifica uma linha, atualizando o estado de comentário em bloco.
+func scanLine(s string, syn *langSyntax, inBlock *int) (hasCode, hasComment bool) {
+	for {
+		if *inBlock >= 0 {
+			hasComment = true
+			end := syn.Block[*inBlock][1]
+			i := strings.Index(s, end)
+			if i < 0 {
+				return
+			}
+			s = s[i+len(end):]
+			*inBlock = -1
+		}
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return
+		}
+		// próximo marcador de comentário (linha ou bloco) a partir daqui
+		pos, block, isLine := len(s), -1, false
+		for _, lc := range syn.Line {
+			if i := strings.Index(s, lc); i >= 0 && i < pos {
+				pos, isLine = i, true
+			}
+		}
+		for bi, bl := range syn.Block {
+			if i := strings.Index(s, bl[0]); i >= 0 && (i < pos || (i == pos && isLine)) {
+				pos, block, isLine = i, bi, false
+			}
+		}
+		if pos > 0 {
+			hasCode = true
+		}
+		switch {
+		case isLine:
+			hasComment = true
+			return
+		case block >= 0:
+			*inBlock = block
+			s = s[pos+len(syn.Block[block][0]):]
+		default:
+			return
+		}
+	}
+}
+
+// countFileLOC detecta a linguagem e conta as linhas do arquivo inteiro.
+func countFileLOC(source *files.Source, full, rel string) (FileLOC, bool) {
+	syn := detectLanguage(source, full, strings.ToLower(rel))
+	if syn == nil {
+		return FileLOC{}, false
+	}
+	f, err := source.Open(full)
+	if err != nil {
+		return FileLOC{}, false
+	}
+	defer func() { _ = f.Close() }()
+	code, comment, blank, ok := countLines(f, syn)
+	if !ok {
+		return FileLOC{}, false
+	}
+	return FileLOC{File: rel, Language: syn.Name, Lines: code + comment + blank, Code: code, Comment: comment, Blank: blank}, true
+}
+
+// buildLanguageStats soma as contagens por linguagem (mais código primeiro) e
+// separa os maiores arquivos.
+func buildLanguageStats(locs []FileLOC) *LanguageStats {
+	if len(locs) == 0 {
+		return nil
+	}
+	st := &LanguageStats{}
+	byLang := map[string]*LanguageLOC{}
+	for _, c := range locs {
+		l := byLang[c.Language]
+		if l == nil {
+			l = &LanguageLOC{Language: c.Language}
+			byLang[c.Language] = l
+		}
+		l.Files++
+		l.Code += c.Code
+		l.Comment += c.Comment
+		l.Blank += c.Blank
+	}
+	for _, l := range byLang {
+		st.Languages = append(st.Languages, *l)
+		st.Total.Files += l.Files
+		st.Total.Code += l.Code
+		st.Total.Comment += l.Comment
+		st.Total.Blank += l.Blank
+	}
+	st.Total.Language = "Total"
+	sort.Slice(st.Languages, func(i, j int) bool {
+		a, b := st.Languages[i], st.Languages[j]
+		if a.Code != b.Code {
+			return a.Code > b.Code
+		}
+		return a.Language < b.Language
+	})
+	st.Largest = append([]FileLOC(nil), locs...)
+	sort.Slice(st.Largest, func(i, j int) bool {
+		a, b := st.Largest[i], st.Largest[j]
+		if a.Lines != b.Lines {
+			return a.Lines > b.Lines
+		}
+		return a.File < b.File
+	})
+	if len(st.Largest) > 15 {
+		st.Largest = st.Largest[:15]
+	}
+	return st
+}
@@ -0,0 +1,190 @@
+package collect
+
+import (
+	"bufio"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	ignore "github.com/sabhiram/go-gitignore"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// LFSSummary descreve os arquivos versionados via Git LFS.
+type LFSSummary struct {
+	Patterns []LFSPattern `json:"patterns,omitempty"`
+	Dirs     []LFSDir     `json:"dirs,omitempty"`
+	Files    int          `json:"files"`
+	Bytes    int64        `json:"bytes"`
+}
+
+// LFSPattern é uma linha "padrão filter=lfs" de um .gitattributes.
+type LFSPattern struct {
+	File    string `json:"file"`
+	Pattern string `json:"pattern"`
+}
+
+// LFSDir agrupa os assets LFS de um diretório.
+type LFSDir struct {
+	Dir    string     `json:"dir"`
+	Files  int        `json:"files"`
+	Bytes  int64      `json:"bytes"`
+	Assets []LFSAsset `json:"assets"`
+}
+
+// LFSAsset é um arquivo LFS. Size é o tamanho real: o campo "size" do pointer
+// ou, se o conteúdo já foi baixado (git lfs pull), o tamanho no disco.
+type LFSAsset struct {
+	File    string `json:"file"`
+	Size    int64  `json:"size"`
+	OID     string `json:"oid,omitempty"`
+	Pointer bool   `json:"pointer"` // true se o working tree tem só o pointer
+}
+
+// LargeAsset é um arquivo acima do limite que não está no LFS.
+type LargeAsset struct {
+	File string `json:"file"`
+	Size int64  `json:"size"`
+}
+
+const lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+
+// lfsRule é um padrão de um .gitattributes que liga (ou desliga) filter=lfs.
+type lfsRule struct {
+	dir string
+	gi  *ignore.GitIgnore
+	on  bool
+}
+
+// lfsAttributes avalia os .gitattributes do repo: o da raiz primeiro, os mais
+// profundos por último, e dentro de cada arquivo a última linha que casa vence.
+type lfsAttributes struct {
+	rules []lfsRule
+}
+
+// loadLFSAttributes lê os .gitattributes entre os paths e guarda as regras de filter.
+func loadLFSAttributes(source *files.Source, root string, paths []string) (*lfsAttributes, []LFSPattern) {
+	var attrFiles []string
+	for _, p := range paths {
+		if path.Base(p) == ".gitattributes" {
+			attrFiles = append(attrFiles, p)
+		}
+	}
+	sort.SliceStable(attrFiles, func(i, j int) bool {
+		return strings.Count(attrFiles[i], "/") < strings.Count(attrFiles[j], "/")
+	})
+	la := &lfsAttributes{}
+	var patterns []LFSPattern
+	for _, p := range attrFiles {
+		f, err := source.Open(filepath.Join(root, p))
+		if err != nil {
+			continue
+		}
+		dir := path.Dir(p)
+		sc := bufio.NewScanner(f)
+		for sc.Scan() {
+			fields := strings.Fields(sc.Text())
+			if len(fields) < 2 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "[attr]") {
+				continue
+			}
+			on, found := false, false
+			for _, a := range fields[1:] {
+				switch {
+				case a == "filter=lfs":
+					on, found = true, true
+				case a == "-filter" || a == "!filter" || strings.HasPrefix(a, "filter="):
+					on, found = false, true
+				}
+			}
+			if !found {
+				continue
+			}
+			la.rules = append(la.rules, lfsRule{dir: dir, gi: ignore.CompileIgnoreLines(fields[0]), on: on})
+			if on {
+				patterns = append(patterns, LFSPattern{File: p, Pattern: fields[0]})
+			}
+		}
+		_ = f.Close()
+	}
+	return la, patterns
+}
+
+// tracked diz se o arquivo cai em um padrão filter=lfs.
+func (la *lfsAttributes) tracked(rel string) bool {
+	on := false
+	for _, r := range la.rules {
+		sub := rel
+		if r.dir != "." {
+			if !strings.HasPrefix(rel, r.dir+"/") {
+				continue
+			}
+			sub = strings.TrimPrefix(rel, r.dir+"/")
+		}
+		if r.gi.MatchesPath(sub) {
+			on = r.on
+		}
+	}
+	return on
+}
+
+// parseLFSPointer reconhece um pointer file do Git LFS e devolve oid e size.
+func parseLFSPointer(source *files.Source, full string, size int64) (oid string, realSize int64, ok bool) {
+	if size > 1024 {
+		return "", 0, false
+	}
+	head, err := source.ReadHead(full, 1024)
+	if err != nil || !strings.HasPrefix(head, lfsPointerVersion) {
+		return "", 0, false
+	}
+	for _, ln := range strings.Split(head, "\n") {
+		k, v, _ := strings.Cut(strings.TrimSpace(ln), " ")
+		switch k {
+		case "oid":
+			oid = v
+		case "size":
+			realSize, _ = strconv.ParseInt(v, 10, 64)
+		}
+	}
+	return oid, realSize, true
+}
+
+// buildLFS agrupa os assets por diretório (maiores primeiro).
+func buildLFS(assets []LFSAsset, patterns []LFSPattern) *LFSSummary {
+	if len(assets) == 0 && len(patterns) == 0 {
+		return nil
+	}
+	s := &LFSSummary{Patterns: patterns}
+	byDir := map[string]*LFSDir{}
+	for _, a := range assets {
+		dir := path.Dir(a.File)
+		d := byDir[dir]
+		if d == nil {
+			d = &LFSDir{Dir: dir}
+			byDir[dir] = d
+		}
+		d.Files++
+		d.Bytes += a.Size
+		d.Assets = append(d.Assets, a)
+		s.Files++
+		s.Bytes += a.Size
+	}
+	for _, d := range byDir {
+		sort.Slice(d.Assets, func(i, j int) bool {
+			if d.Assets[i].Size != d.Assets[j].Size {
+				return d.Assets[i].Size > d.Assets[j].Size
+			}
+			return d.Assets[i].File < d.Assets[j].File
+		})
+		s.Dirs = append(s.Dirs, *d)
+	}
+	sort.Slice(s.Dirs, func(i, j int) bool {
+		if s.Dirs[i].Bytes != s.Dirs[j].Bytes {
+			return s.Dirs[i].Bytes > s.Dirs[j].Bytes
+		}
+		return s.Dirs[i].Dir < s.Dirs[j].Dir
+	})
+	return s
+}
@@ -0,0 +1,374 @@
+package collect
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// APISpec descreve um documento OpenAPI 3 / Swagger 2 e seu catálogo de endpoints.
+// Unparsed explica por que um spec reconhecido não pôde ser lido (grande demais, YAML inválido).
+type APISpec struct {
+	File       string         `json:"file"`
+	Format     string         `json:"format"` // "openapi 3.0.3" | "swagger 2.0"
+	Title      string         `json:"title,omitempty"`
+	Version    string         `json:"version,omitempty"`
+	Servers    []string       `json:"servers,omitempty"`
+	Operations []APIOperation `json:"operations,omitempty"`
+	RefFiles   []string       `json:"ref_files,omitempty"` // arquivos externos alcançados via $ref
+	Unparsed   string         `json:"unparsed,omitempty"`
+}
+
+// APIOperation é um par path + método HTTP de um spec.
+type APIOperation struct {
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	OperationID string   `json:"operation_id,omitempty"`
+	Summary     string   `json:"summary,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Request     string   `json:"request,omitempty"`   // schema do corpo
+	Responses   []string `json:"responses,omitempty"` // "200 User", "404 Error"
+}
+
+var (
+	openAPIYAMLRe = regexp.MustCompile(`(?m)^(openapi|swagger)\s*:`)
+	httpMethods   = []string{"get", "post", "put", "patch", "delete", "head", "options", "trace"}
+)
+
+// maxSpecBytes limita a leitura de um spec (e dos arquivos de $ref). Specs são lidos
+// inteiros, independentemente de -max-bytes: cortados no meio, não decodificam.
+var maxSpecBytes int64 = 32 << 20
+
+func isAPISpecCandidate(source *files.Source, full, lower string) bool {
+	return apiSpecKind(source, full, lower) != ""
+}
+
+// apiSpecKind olha o início de YAML/JSON à procura da chave de topo
+// openapi/swagger ("openapi") ou asyncapi ("asyncapi"); uma dependência
+// "swagger" num package.json não conta.
+func apiSpecKind(source *files.Source, full, lower string) string {
+	ext := path.Ext(lower)
+	if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+		return ""
+	}
+	head, err := source.ReadHead(full, 4096)
+	if err != nil {
+		return ""
+	}
+	switch {
+	case ext == ".json" && jsonHasTopLevelKey(head, "openapi", "swagger"),
+		ext != ".json" && openAPIYAMLRe.MatchString(head):
+		return "openapi"
+	case ext == ".json" && jsonHasTopLevelKey(head, "asyncapi"),
+		ext != ".json" && asyncAPIYAMLRe.MatchString(head):
+		return "asyncapi"
+	}
+	return ""
+}
+
+// jsonHasTopLevelKey percorre os tokens do objeto raiz (o trecho lido pode
+// terminar no meio do documento) e diz se alguma das chaves está no primeiro nível.
+func jsonHasTopLevelKey(head string, keys ...string) bool {
+	dec := json.NewDecoder(strings.NewReader(head))
+	depth, expectKey := 0, false
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return false
+		}
+		if d, ok := tok.(json.Delim); ok {
+			switch d {
+			case '{', '[':
+				depth++
+				if depth == 1 && d == '[' {
+					return false
+				}
+				expectKey = depth == 1
+			default:
+				depth--
+				if depth == 0 {
+					return false
+				}
+				expectKey = depth == 1 // terminou um valor do primeiro nível
+			}
+			continue
+		}
+		if depth != 1 {
+			continue
+		}
+		if expectKey {
+			for _, k := range keys {
+				if tok == k {
+					return true
+				}
+			}
+		}
+		expectKey = !expectKey
+	}
+}
+
+// specLoader carrega documentos referenciados por $ref (um cache por spec).
+type specLoader struct {
+	source *files.Source
+	root   string
+	docs   map[string]any // rel -> documento
+}
+
+func (l *specLoader) load(rel string) any {
+	doc, _ := l.loadDoc(rel)
+	return doc
+}
+
+// loadDoc é load com o motivo da falha (para o documento raiz).
+func (l *specLoader) loadDoc(rel string) (any, error) {
+	if d, ok := l.docs[rel]; ok {
+		return d, nil
+	}
+	l.docs[rel] = nil // evita ciclos
+	full := filepath.Join(l.root, rel)
+	if st, err := l.source.Stat(full); err == nil && st.Size() > maxSpecBytes {
+		return nil, fmt.Errorf("larger than %d MiB, not parsed", maxSpecBytes>>20)
+	}
+	head, err := l.source.ReadHead(full, maxSpecBytes)
+	if err != nil {
+		return nil, err
+	}
+	var doc any
+	if strings.HasSuffix(strings.ToLower(rel), ".json") {
+		err = json.Unmarshal([]byte(head), &doc)
+	} else {
+		err = yaml.Unmarshal([]byte(head), &doc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not be parsed: %s", limitLine(err.Error(), 160))
+	}
+	doc = normalizeYAML(doc)
+	l.docs[rel] = doc
+	return doc, nil
+}
+
+// deref segue $ref locais ("#/components/..."), relativos ("schemas/user.yaml#/User")
+// ou para arquivos inteiros; devolve o nó resolvido e o arquivo em que está.
+func (l *specLoader) deref(node any, file string) (any, string) {
+	for i := 0; i < 10; i++ {
+		m, ok := node.(map[string]any)
+		if !ok {
+			return node, file
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok {
+			return node, file
+		}
+		target, pointer, _ := strings.Cut(ref, "#")
+		if strings.Contains(target, "://") {
+			return node, file // $ref remoto: não resolvido
+		}
+		if target != "" {
+			file = path.Join(path.Dir(file), target)
+		}
+		doc := l.load(file)
+		node = jsonPointer(doc, pointer)
+		if node == nil {
+			return nil, file
+		}
+	}
+	return node, file
+}
+
+// jsonPointer navega "/a/b~1c" (RFC 6901); ponteiro vazio é o documento.
+func jsonPointer(doc any, pointer string) any {
+	cur := doc
+	for _, seg := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if seg == "" {
+			continue
+		}
+		seg = strings.NewReplacer("~1", "/", "~0", "~").Replace(seg)
+		m, ok := cur.(map[string]any)
+		if !ok {
+			return nil
+		}
+		cur = m[seg]
+	}
+	return cur
+}
+
+// parseOpenAPI lê o spec e monta o catálogo de operações, seguindo $refs.
+// Um spec reconhecido que não pôde ser lido volta só com Unparsed preenchido.
+func parseOpenAPI(source *files.Source, root, rel string) (*APISpec, error) {
+	l := &specLoader{source: source, root: root, docs: map[string]any{}}
+	raw, err := l.loadDoc(rel)
+	if err != nil && apiSpecKind(source, filepath.Join(root, rel), strings.ToLower(rel)) == "openapi" {
+		return &APISpec{File: rel, Format: "openapi", Unparsed: err.Error()}, nil
+	}
+	doc, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("openapi: %s não é um documento", rel)
+	}
+	spec := &APISpec{File: rel}
+	if v := settingString(doc["openapi"]); v != "" {
+		spec.Format = "openapi " + v
+	} else if v := settingString(doc["swagger"]); v != "" {
+		spec.Format = "swagger " + v
+	} else {
+		return nil, fmt.Errorf("openapi: %s sem chave openapi/swagger", rel)
+	}
+	spec.Title = settingString(getPath(doc, "info.title"))
+	spec.Version = settingString(getPath(doc, "info.version"))
+	spec.Servers = collectField(doc["servers"], "url")
+	if host := settingString(doc["host"]); host != "" {
+		schemes := toStrings(doc["schemes"])
+		if len(schemes) == 0 {
+			schemes = []string{"https"}
+		}
+		for _, s := range schemes {
+			spec.Servers = append(spec.Servers, s+"://"+host+settingString(doc["basePath"]))
+		}
+	}
+
+	paths, _ := doc["paths"].(map[string]any)
+	for _, p := range sortedKeys(paths) {
+		item, itemFile := l.deref(paths[p], rel)
+		im, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		for _, method := range httpMethods {
+			op, ok := im[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			o := APIOperation{
+				Method:      strings.ToUpper(method),
+				Path:        p,
+				OperationID: settingString(op["operationId"]),
+				Summary:     limitLine(settingString(firstNonNil(op["summary"], op["description"])), 120),
+				Tags:        toStrings(op["tags"]),
+			}
+			o.Request = l.requestSchema(op, itemFile)
+			o.Responses = l.responseSchemas(op, itemFile)
+			spec.Operations = append(spec.Operations, o)
+		}
+	}
+	for f := range l.docs {
+		if f != rel && l.docs[f] != nil {
+			spec.RefFiles = append(spec.RefFiles, f)
+		}
+	}
+	sort.Strings(spec.RefFiles)
+	return spec, nil
+}
+
+// requestSchema: requestBody (OAS3) ou parâmetro in: body (Swagger 2).
+func (l *specLoader) requestSchema(op map[string]any, file string) string {
+	if rb, f := l.deref(op["requestBody"], file); rb != nil {
+		return l.contentSchema(rb, f)
+	}
+	params, _ := op["parameters"].([]any)
+	for _, p := range params {
+		pm, f := l.deref(p, file)
+		if settingString(getPath(pm, "in")) == "body" {
+			return l.schemaName(getPath(pm, "schema"), f)
+		}
+	}
+	return ""
+}
+
+func (l *specLoader) responseSchemas(op map[string]any, file string) []string {
+	resps, _ := op["responses"].(map[string]any)
+	var out []string
+	for _, code := range sortedKeys(resps) {
+		r, f := l.deref(resps[code], file)
+		name := l.contentSchema(r, f)
+		if name == "" {
+			name = l.schemaName(getPath(r, "schema"), f) // Swagger 2
+		}
+		out = append(out, strings.TrimSpace(code+" "+name))
+	}
+	return out
+}
+
+// contentSchema pega o schema do primeiro media type (preferindo JSON).
+func (l *specLoader) contentSchema(node any, file string) string {
+	content, ok := getPath(node, "content").(map[string]any)
+	if !ok || len(content) == 0 {
+		return ""
+	}
+	keys := sortedKeys(content)
+	pick := keys[0]
+	for _, k := range keys {
+		if strings.Contains(k, "json") {
+			pick = k
+			break
+		}
+	}
+	return l.schemaName(getPath(content[pick], "schema"), file)
+}
+
+// schemaName devolve o nome do schema: último segmento do $ref, []Item para
+// arrays e o "type" para schemas inline.
+func (l *specLoader) schemaName(s any, file string) string {
+	m, ok := s.(map[string]any)
+	if !ok {
+		return ""
+	}
+	if ref, ok := m["$ref"].(string); ok {
+		target, pointer, _ := strings.Cut(ref, "#")
+		if target != "" && !strings.Contains(target, "://") {
+			l.load(path.Join(path.Dir(file), target)) // registra o arquivo em RefFiles
+		}
+		if pointer != "" {
+			return pointer[strings.LastIndex(pointer, "/")+1:]
+		}
+		base := path.Base(target)
+		return strings.TrimSuffix(base, path.Ext(base))
+	}
+	if settingString(m["type"]) == "array" {
+		if it := l.schemaName(m["items"], file); it != "" {
+			return "[]" + it
+		}
+	}
+	for _, k := range []string{"allOf", "oneOf", "anyOf"} {
+		if parts, ok := m[k].([]any); ok {
+			var names []string
+			for _, p := range parts {
+				if n := l.schemaName(p, file); n != "" {
+					names = append(names, n)
+				}
+			}
+			return k + "(" + strings.Join(names, ", ") + ")"
+		}
+	}
+	return settingString(m["type"])
+}
+
+// normalizeYAML converte map[any]any (chaves numéricas como códigos HTTP)
+// em map[string]any, recursivamente.
+func normalizeYAML(v any) any {
+	switch x := v.(type) {
+	case map[any]any:
+		out := make(map[string]any, len(x))
+		for k, val := range x {
+			out[fmt.Sprint(k)] = normalizeYAML(val)
+		}
+		return out
+	case map[string]any:
+		for k, val := range x {
+			x[k] = normalizeYAML(val)
+		}
+		return x
+	case []any:
+		for i := range x {
+			x[i] = normalizeYAML(x[i])
+		}
+		return x
+	}
+	return v
+}
@@ -0,0 +1,96 @@
+package collect
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIsAPISpecCandidate(t *testing.T) {
+	tests := []struct {
+		name, file, src string
+		want            bool
+	}{
+		{"openapi yaml", "api.yaml", "# spec\nopenapi: 3.0.3\ninfo: {}\n", true},
+		{"swagger yaml", "api.yml", "swagger: \"2.0\"\n", true},
+		{"asyncapi yaml", "events.yaml", "asyncapi: 2.6.0\n", true},
+		{"chave aninhada em yaml", "values.yaml", "docs:\n  openapi: true\n", false},
+		{"openapi json", "api.json", `{"openapi": "3.1.0", "paths": {}}`, true},
+		{"asyncapi json depois de outras chaves", "a.json", `{"info": {"title": "x", "openapi": 1}, "tags": ["a"], "asyncapi": "2.0.0"}`, true},
+		{"swagger json cortado", "s.json", `{"x-meta": [1, 2, {"a": null}], "swagger": "2.0", "paths": {"/a": {`, true},
+		{"package.json com script openapi", "package.json", `{"name": "web", "scripts": {"openapi": "gen"}, "dependencies": {"swagger": "^1.0.0"}}`, false},
+		{"valor string igual à chave", "x.json", `{"kind": "openapi", "n": 1}`, false},
+		{"array na raiz", "list.json", `[{"openapi": "3.0.0"}]`, false},
+		{"extensão errada", "api.txt", "openapi: 3.0.0\n", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isAPISpecCandidate(nil, writeTemp(t, tt.file, tt.src), tt.file); got != tt.want {
+				t.Errorf("isAPISpecCandidate(nil, %s) = %v, want %v", tt.file, got, tt.want)
+			}
+		})
+	}
+}
+
+// bigSpec gera um openapi.yaml com n operações e descrições longas.
+func bigSpec(n int) string {
+	var b strings.Builder
+	b.WriteString("openapi: 3.0.3\ninfo:\n  title: Big\n  version: \"1\"\npaths:\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "  /items/%d:\n    get:\n      operationId: getItem%d\n      description: %s\n      responses:\n        \"200\":\n          description: ok\n",
+			i, i, strings.Repeat("texto longo ", 40))
+	}
+	return b.String()
+}
+
+func TestParseOpenAPILargeSpec(t *testing.T) {
+	root := t.TempDir()
+	src := bigSpec(500)
+	if len(src) <= 64<<10 {
+		t.Fatalf("spec de teste pequena demais: %d bytes", len(src))
+	}
+	if err := os.WriteFile(filepath.Join(root, "openapi.yaml"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	sum, err := Scan(context.Background(), Config{Root: root, MaxFileBytes: 64 << 10, Threads: 2, TreeDepth: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sum.APISpecs) != 1 || sum.APISpecs[0].Unparsed != "" || len(sum.APISpecs[0].Operations) != 500 {
+		t.Fatalf("APISpecs = %+v, want 1 spec with 500 operations", sum.APISpecs)
+	}
+
+	old := maxSpecBytes
+	maxSpecBytes = 32 << 10
+	defer func() { maxSpecBytes = old }()
+	spec, err := parseOpenAPI(nil, root, "openapi.yaml")
+	if err != nil || spec.Unparsed == "" || len(spec.Operations) != 0 {
+		t.Errorf("above maxSpecBytes: spec = %+v, err = %v; want an Unparsed note", spec, err)
+	}
+}
+
+func TestParseAPISpecUnparsed(t *testing.T) {
+	root := t.TempDir()
+	for name, src := range map[string]string{
+		"bad.yaml":   "openapi: 3.0.0\npaths:\n  /a: [unclosed\n",
+		"async.yaml": "asyncapi: 2.6.0\nchannels: {a: [\n",
+	} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if spec, err := parseOpenAPI(nil, root, "bad.yaml"); err != nil || !strings.HasPrefix(spec.Unparsed, "could not be parsed") {
+		t.Errorf("parseOpenAPI(nil, bad.yaml) = %+v, %v", spec, err)
+	}
+	// um AsyncAPI inválido não vira nota de OpenAPI
+	if _, err := parseOpenAPI(nil, root, "async.yaml"); err == nil {
+		t.Errorf("parseOpenAPI(nil, async.yaml) should fail")
+	}
+	if as, err := parseAsyncAPI(nil, root, "async.yaml"); err != nil || as.Unparsed == "" {
+		t.Errorf("parseAsyncAPI(nil, async.yaml) = %+v, %v", as, err)
+	}
+}
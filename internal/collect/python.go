@@ -0,0 +1,565 @@
+package collect
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// PythonProject consolida os manifestos Python de um diretório
+// (pyproject.toml, setup.cfg, Pipfile, requirements*.txt).
+type PythonProject struct {
+	Dir             string              `json:"dir"`
+	Name            string              `json:"name,omitempty"`
+	Version         string              `json:"version,omitempty"`
+	Sources         []string            `json:"sources"`         // manifestos que contribuíram
+	Tools           []string            `json:"tools,omitempty"` // pep621 | poetry | hatch | pdm | uv | setuptools | pipenv | pip
+	BuildBackend    string              `json:"build_backend,omitempty"`
+	RequiresPython  string              `json:"requires_python,omitempty"`
+	Dependencies    []string            `json:"dependencies,omitempty"`
+	DevDependencies []string            `json:"dev_dependencies,omitempty"`
+	Extras          map[string][]string `json:"extras,omitempty"`
+	Scripts         map[string]string   `json:"scripts,omitempty"` // console scripts: nome -> módulo:função
+	Layout          string              `json:"layout,omitempty"`  // src | flat
+	Packages        []string            `json:"packages,omitempty"`
+}
+
+// pythonManifestKind identifica manifestos Python pelo nome do arquivo.
+func pythonManifestKind(lower string) string {
+	base := path.Base(lower)
+	switch {
+	case base == "pyproject.toml":
+		return "pyproject"
+	case base == "setup.cfg":
+		return "setup.cfg"
+	case base == "pipfile":
+		return "pipfile"
+	case (strings.HasSuffix(base, ".txt") || strings.HasSuffix(base, ".in")) &&
+		(strings.Contains(base, "requirements") || strings.HasSuffix(path.Dir(lower), "requirements")):
+		return "requirements"
+	}
+	return ""
+}
+
+// parsePythonManifest lê um manifesto e devolve um fragmento do projeto do
+// diretório; fragmentos do mesmo diretório são mesclados depois do Walk.
+func parsePythonManifest(source *files.Source, full, rel, kind string, maxBytes int64) (*PythonProject, error) {
+	head, err := source.ReadHead(full, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	pp := &PythonProject{Dir: path.Dir(rel), Sources: []string{rel}}
+	// requirements/dev.txt pertence ao projeto do diretório pai
+	if kind == "requirements" && path.Base(pp.Dir) == "requirements" {
+		pp.Dir = path.Dir(pp.Dir)
+	}
+	switch kind {
+	case "pyproject":
+		parsePyproject(pp, parseTOML(head))
+	case "pipfile":
+		doc := parseTOML(head)
+		pp.Tools = append(pp.Tools, "pipenv")
+		pp.RequiresPython = settingString(firstNonNil(getPath(doc, "requires.python_full_version"), getPath(doc, "requires.python_version")))
+		pp.Dependencies = append(pp.Dependencies, pipenvDeps(getPath(doc, "packages"))...)
+		pp.DevDependencies = append(pp.DevDependencies, pipenvDeps(getPath(doc, "dev-packages"))...)
+	case "setup.cfg":
+		parseSetupCfg(pp, parseINI(head))
+	case "requirements":
+		pp.Tools = append(pp.Tools, "pip")
+		reqs := parseRequirements(head)
+		base := strings.ToLower(path.Base(rel))
+		if isDevRequirements(base) {
+			pp.DevDependencies = append(pp.DevDependencies, reqs...)
+		} else {
+			pp.Dependencies = append(pp.Dependencies, reqs...)
+		}
+	}
+	return pp, nil
+}
+
+// parsePyproject cobre PEP 621 ([project]), PEP 735 ([dependency-groups]),
+// Poetry, Hatch, PDM, uv e setuptools.
+func parsePyproject(pp *PythonProject, doc map[string]any) {
+	pp.BuildBackend = settingString(getPath(doc, "build-system.build-backend"))
+
+	if proj, ok := doc["project"].(map[string]any); ok {
+		pp.Tools = append(pp.Tools, "pep621")
+		pp.Name = settingString(proj["name"])
+		pp.Version = settingString(proj["version"])
+		if pp.Version == "" && containsString(toStrings(proj["dynamic"]), "version") {
+			pp.Version = "dynamic"
+		}
+		pp.RequiresPython = settingString(proj["requires-python"])
+		pp.Dependencies = append(pp.Dependencies, normalizeRequirements(toStrings(proj["dependencies"]))...)
+		if opt, ok := proj["optional-dependencies"].(map[string]any); ok {
+			for extra, deps := range opt {
+				pp.addExtra(extra, normalizeRequirements(toStrings(deps)))
+			}
+		}
+		pp.addScripts(proj["scripts"])
+		pp.addScripts(proj["gui-scripts"])
+		pp.addScripts(getPath(proj, "entry-points.console_scripts"))
+	}
+	if groups, ok := doc["dependency-groups"].(map[string]any); ok {
+		for _, g := range groups {
+			pp.DevDependencies = append(pp.DevDependencies, normalizeRequirements(toStrings(g))...)
+		}
+	}
+
+	if poetry, ok := getPath(doc, "tool.poetry").(map[string]any); ok {
+		pp.Tools = append(pp.Tools, "poetry")
+		if pp.Name == "" {
+			pp.Name = settingString(poetry["name"])
+		}
+		if pp.Version == "" {
+			pp.Version = settingString(poetry["version"])
+		}
+		deps := poetryDeps(poetry["dependencies"])
+		if py, ok := deps["python"]; ok {
+			if pp.RequiresPython == "" {
+				pp.RequiresPython = py
+			}
+			delete(deps, "python")
+		}
+		pp.Dependencies = append(pp.Dependencies, poetryDepList(deps)...)
+		// Poetry < 1.2: [tool.poetry.dev-dependencies]; >= 1.2: [tool.poetry.group.<g>.dependencies]
+		pp.DevDependencies = append(pp.DevDependencies, poetryDepList(poetryDeps(poetry["dev-dependencies"]))...)
+		if groups, ok := poetry["group"].(map[string]any); ok {
+			for name, g := range groups {
+				list := poetryDepList(poetryDeps(getPath(g, "dependencies")))
+				if name == "main" {
+					pp.Dependencies = append(pp.Dependencies, list...)
+				} else {
+					pp.DevDependencies = append(pp.DevDependencies, list...)
+				}
+			}
+		}
+		if extras, ok := poetry["extras"].(map[string]any); ok {
+			for extra, names := range extras {
+				pp.addExtra(extra, toStrings(names))
+			}
+		}
+		pp.addScripts(poetry["scripts"])
+		if pkgs, ok := poetry["packages"].([]any); ok {
+			for _, it := range pkgs {
+				if inc := settingString(getPath(it, "include")); inc != "" {
+					pp.Packages = append(pp.Packages, inc)
+				}
+				if settingString(getPath(it, "from")) == "src" {
+					pp.Layout = "src"
+				}
+			}
+		}
+	}
+
+	if hatch, ok := getPath(doc, "tool.hatch").(map[string]any); ok {
+		pp.Tools = append(pp.Tools, "hatch")
+		if envs, ok := hatch["envs"].(map[string]any); ok {
+			for _, env := range envs {
+				pp.DevDependencies = append(pp.DevDependencies, normalizeRequirements(toStrings(getPath(env, "dependencies")))...)
+				pp.DevDependencies = append(pp.DevDependencies, normalizeRequirements(toStrings(getPath(env, "extra-dependencies")))...)
+			}
+		}
+		for _, p := range toStrings(getPath(hatch, "build.targets.wheel.packages")) {
+			if strings.HasPrefix(p, "src/") {
+				pp.Layout = "src"
+			}
+			pp.Packages = append(pp.Packages, path.Base(p))
+		}
+	}
+
+	if pdm, ok := getPath(doc, "tool.pdm").(map[string]any); ok {
+		pp.Tools = append(pp.Tools, "pdm")
+		if groups, ok := pdm["dev-dependencies"].(map[string]any); ok {
+			for _, g := range groups {
+				pp.DevDependencies = append(pp.DevDependencies, normalizeRequirements(toStrings(g))...)
+			}
+		}
+	}
+
+	if uv, ok := getPath(doc, "tool.uv").(map[string]any); ok {
+		pp.Tools = append(pp.Tools, "uv")
+		pp.DevDependencies = append(pp.DevDependencies, normalizeRequirements(toStrings(uv["dev-dependencies"]))...)
+	}
+
+	if st, ok := getPath(doc, "tool.setuptools").(map[string]any); ok {
+		pp.Tools = append(pp.Tools, "setuptools")
+		if containsString(toStrings(getPath(st, "packages.find.where")), "src") {
+			pp.Layout = "src"
+		}
+		if pd, ok := st["package-dir"].(map[string]any); ok && settingString(pd[""]) == "src" {
+			pp.Layout = "src"
+		}
+		pp.Packages = append(pp.Packages, toStrings(st["packages"])...)
+	}
+}
+
+// parseSetupCfg lê metadata/options do setuptools declarativo.
+func parseSetupCfg(pp *PythonProject, ini map[string]map[string]string) {
+	meta, opts := ini["metadata"], ini["options"]
+	if meta == nil && opts == nil {
+		return // setup.cfg só com [flake8]/[tool:pytest] etc.
+	}
+	pp.Tools = append(pp.Tools, "setuptools")
+	pp.Name = meta["name"]
+	pp.Version = meta["version"]
+	pp.RequiresPython = opts["python_requires"]
+	pp.Dependencies = append(pp.Dependencies, normalizeRequirements(iniList(opts["install_requires"]))...)
+	pp.DevDependencies = append(pp.DevDependencies, normalizeRequirements(iniList(opts["tests_require"]))...)
+	if pd := iniList(opts["package_dir"]); len(pd) > 0 {
+		for _, m := range pd {
+			if k, v, ok := strings.Cut(m, "="); ok && strings.TrimSpace(k) == "" && strings.TrimSpace(v) == "src" {
+				pp.Layout = "src"
+			}
+		}
+	}
+	for extra, deps := range ini["options.extras_require"] {
+		pp.addExtra(extra, normalizeRequirements(iniList(deps)))
+	}
+	for _, ep := range iniList(ini["options.entry_points"]["console_scripts"]) {
+		if name, target, ok := strings.Cut(ep, "="); ok {
+			if pp.Scripts == nil {
+				pp.Scripts = map[string]string{}
+			}
+			pp.Scripts[strings.TrimSpace(name)] = strings.TrimSpace(target)
+		}
+	}
+}
+
+// parseINI é um leitor INI mínimo (seções, "k = v" ou "k: v", valores com
+// continuação indentada). Chaves de seção e de item ficam em minúsculas.
+func parseINI(src string) map[string]map[string]string {
+	out := map[string]map[string]string{}
+	section, key := "", ""
+	for _, ln := range strings.Split(src, "\n") {
+		ln = strings.TrimRight(ln, "\r")
+		t := strings.TrimSpace(ln)
+		if t == "" || strings.HasPrefix(t, "#") || strings.HasPrefix(t, ";") {
+			continue
+		}
+		if strings.HasPrefix(t, "[") && strings.HasSuffix(t, "]") {
+			section, key = strings.ToLower(strings.TrimSpace(t[1:len(t)-1])), ""
+			if out[section] == nil {
+				out[section] = map[string]string{}
+			}
+			continue
+		}
+		if section == "" {
+			continue
+		}
+		if (ln[0] == ' ' || ln[0] == '\t') && key != "" {
+			out[section][key] += "\n" + t
+			continue
+		}
+		i := strings.IndexAny(t, "=:")
+		if i < 0 {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(t[:i]))
+		out[section][key] = strings.TrimSpace(t[i+1:])
+	}
+	return out
+}
+
+// iniList divide um valor multilinha/por vírgula do setup.cfg.
+func iniList(v string) []string {
+	var out []string
+	for _, ln := range strings.Split(v, "\n") {
+		ln = strings.TrimSpace(ln)
+		if ln == "" || strings.HasPrefix(ln, "#") {
+			continue
+		}
+		if strings.Contains(ln, ",") && !strings.ContainsAny(ln, "<>=!~") {
+			for _, p := range strings.Split(ln, ",") {
+				if p = strings.TrimSpace(p); p != "" {
+					out = append(out, p)
+				}
+			}
+			continue
+		}
+		out = append(out, ln)
+	}
+	return out
+}
+
+// parseRequirements lê um requirements.txt (pip/pip-tools), ignorando opções
+// (-r, -c, -e, --hash, --index-url) e comentários.
+func parseRequirements(src string) []string {
+	var out []string
+	src = strings.ReplaceAll(src, "\\\r\n", " ")
+	src = strings.ReplaceAll(src, "\\\n", " ")
+	for _, ln := range strings.Split(src, "\n") {
+		if i := strings.Index(ln, " #"); i >= 0 {
+			ln = ln[:i]
+		}
+		ln = strings.TrimSpace(ln)
+		if ln == "" || strings.HasPrefix(ln, "#") || strings.HasPrefix(ln, "-") {
+			continue
+		}
+		if i := strings.Index(ln, " --"); i >= 0 {
+			ln = ln[:i]
+		}
+		out = append(out, ln)
+	}
+	return normalizeRequirements(out)
+}
+
+var devRequirementTokens = map[string]bool{
+	"dev": true, "develop": true, "development": true, "test": true, "tests": true, "testing": true,
+	"lint": true, "doc": true, "docs": true, "ci": true, "typing": true, "types": true,
+}
+
+// isDevRequirements olha as palavras do nome (requirements-dev.txt, test.txt,
+// requirements_docs.in); "docker" ou "prototype" não contam.
+func isDevRequirements(base string) bool {
+	for _, tok := range strings.FieldsFunc(base, func(r rune) bool { return r == '-' || r == '_' || r == '.' }) {
+		if devRequirementTokens[tok] {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeRequirements compacta espaços de cada especificador PEP 508.
+func normalizeRequirements(in []string) []string {
+	var out []string
+	for _, r := range in {
+		if r = strings.Join(strings.Fields(r), " "); r != "" {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// poetryDeps converte a tabela de dependências do Poetry em nome -> restrição.
+func poetryDeps(v any) map[string]string {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil
+	}
+	out := map[string]string{}
+	for name, spec := range m {
+		out[name] = poetrySpec(spec)
+	}
+	return out
+}
+
+func poetrySpec(spec any) string {
+	switch x := spec.(type) {
+	case string:
+		return x
+	case map[string]any:
+		for _, k := range []string{"version", "path", "git", "url"} {
+			if s := settingString(x[k]); s != "" {
+				if k == "version" {
+					return s
+				}
+				return "(" + k + " " + s + ")"
+			}
+		}
+	case []any:
+		// múltiplas restrições (por plataforma/versão): usa a primeira
+		if len(x) > 0 {
+			return poetrySpec(x[0])
+		}
+	}
+	return ""
+}
+
+func poetryDepList(deps map[string]string) []string {
+	var out []string
+	for name, spec := range deps {
+		if spec == "" || spec == "*" {
+			out = append(out, name)
+		} else {
+			out = append(out, name+" "+spec)
+		}
+	}
+	return out
+}
+
+// pipenvDeps lê [packages]/[dev-packages] do Pipfile.
+func pipenvDeps(v any) []string {
+	return poetryDepList(poetryDeps(v))
+}
+
+func (pp *PythonProject) addExtra(name string, deps []string) {
+	if pp.Extras == nil {
+		pp.Extras = map[string][]string{}
+	}
+	pp.Extras[name] = append(pp.Extras[name], deps...)
+}
+
+func (pp *PythonProject) addScripts(v any) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return
+	}
+	if pp.Scripts == nil {
+		pp.Scripts = map[string]string{}
+	}
+	for name, target := range m {
+		if s := settingString(target); s != "" {
+			pp.Scripts[name] = s
+		}
+	}
+}
+
+// mergePythonProjects junta fragmentos por diretório, completa o layout
+// (src/ vs flat) a partir dos arquivos varridos e ordena tudo.
+func mergePythonProjects(frags []PythonProject, paths []string) []PythonProject {
+	// ordem determinística: pyproject > setup.cfg > Pipfile > requirements
+	sort.SliceStable(frags, func(i, j int) bool {
+		ri, rj := pythonManifestRank(frags[i].Sources[0]), pythonManifestRank(frags[j].Sources[0])
+		if ri != rj {
+			return ri < rj
+		}
+		return frags[i].Sources[0] < frags[j].Sources[0]
+	})
+	byDir := map[string]*PythonProject{}
+	var dirs []string
+	for _, f := range frags {
+		pp, ok := byDir[f.Dir]
+		if !ok {
+			cp := f
+			byDir[f.Dir] = &cp
+			dirs = append(dirs, f.Dir)
+			continue
+		}
+		pp.Sources = append(pp.Sources, f.Sources...)
+		pp.Tools = append(pp.Tools, f.Tools...)
+		if pp.Name == "" {
+			pp.Name = f.Name
+		}
+		if pp.Version == "" {
+			pp.Version = f.Version
+		}
+		if pp.RequiresPython == "" {
+			pp.RequiresPython = f.RequiresPython
+		}
+		if pp.BuildBackend == "" {
+			pp.BuildBackend = f.BuildBackend
+		}
+		if pp.Layout == "" {
+			pp.Layout = f.Layout
+		}
+		pp.Dependencies = append(pp.Dependencies, f.Dependencies...)
+		pp.DevDependencies = append(pp.DevDependencies, f.DevDependencies...)
+		pp.Packages = append(pp.Packages, f.Packages...)
+		for k, v := range f.Extras {
+			pp.addExtra(k, v)
+		}
+		for k, v := range f.Scripts {
+			if pp.Scripts == nil {
+				pp.Scripts = map[string]string{}
+			}
+			pp.Scripts[k] = v
+		}
+	}
+	sort.Strings(dirs)
+
+	out := make([]PythonProject, 0, len(dirs))
+	for _, d := range dirs {
+		pp := byDir[d]
+		layout, pkgs := detectPythonLayout(d, dirs, paths)
+		if pp.Layout == "" {
+			pp.Layout = layout
+		}
+		pp.Packages = dedupeSorted(append(pp.Packages, pkgs...))
+		pp.Sources = dedupeSorted(pp.Sources)
+		pp.Tools = dedupeSorted(pp.Tools)
+		pp.Dependencies = dedupeSorted(pp.Dependencies)
+		pp.DevDependencies = dedupeSorted(pp.DevDependencies)
+		for k, v := range pp.Extras {
+			pp.Extras[k] = dedupeSorted(v)
+		}
+		out = append(out, *pp)
+	}
+	return out
+}
+
+func pythonManifestRank(rel string) int {
+	switch pythonManifestKind(strings.ToLower(rel)) {
+	case "pyproject":
+		return 0
+	case "setup.cfg":
+		return 1
+	case "pipfile":
+		return 2
+	}
+	return 3
+}
+
+// detectPythonLayout procura pacotes de topo em <dir>/src/<pkg>/ ou
+// <dir>/<pkg>/__init__.py, ignorando subprojetos Python aninhados.
+func detectPythonLayout(dir string, projectDirs, paths []string) (string, []string) {
+	prefix := ""
+	if dir != "." {
+		prefix = dir + "/"
+	}
+	nested := func(p string) bool {
+		for _, o := range projectDirs {
+			if o != dir && strings.HasPrefix(o+"/", prefix) && strings.HasPrefix(p, o+"/") {
+				return true
+			}
+		}
+		return false
+	}
+	var srcPkgs, flatPkgs []string
+	for _, p := range paths {
+		if !strings.HasPrefix(p, prefix) || !strings.HasSuffix(p, ".py") || nested(p) {
+			continue
+		}
+		parts := strings.Split(strings.TrimPrefix(p, prefix), "/")
+		switch {
+		case len(parts) >= 3 && parts[0] == "src":
+			srcPkgs = append(srcPkgs, parts[1])
+		case len(parts) == 2 && parts[1] == "__init__.py" && !isPythonAuxDir(parts[0]):
+			flatPkgs = append(flatPkgs, parts[0])
+		}
+	}
+	switch {
+	case len(srcPkgs) > 0:
+		return "src", srcPkgs
+	case len(flatPkgs) > 0:
+		return "flat", flatPkgs
+	}
+	return "", nil
+}
+
+func isPythonAuxDir(name string) bool {
+	switch name {
+	case "tests", "test", "docs", "examples", "scripts", "benchmarks":
+		return true
+	}
+	return false
+}
+
+// toStrings converte []any (TOML/YAML) em []string.
+func toStrings(v any) []string {
+	items, ok := v.([]any)
+	if !ok {
+		if s, ok := v.(string); ok && s != "" {
+			return []string{s}
+		}
+		return nil
+	}
+	var out []string
+	for _, it := range items {
+		if s, ok := it.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func containsString(list []string, s string) bool {
+	for _, x := range list {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package collect
+
+import "testing"
+
+func TestIsDevRequirements(t *testing.T) {
+	tests := []struct {
+		base string
+		want bool
+	}{
+		{"requirements.txt", false},
+		{"requirements-dev.txt", true},
+		{"requirements_test.in", true},
+		{"requirements.tests.txt", true},
+		{"dev.txt", true},
+		{"docs.txt", true},
+		{"requirements-lint.txt", true},
+		{"requirements-ci.txt", true},
+		{"requirements-typing.txt", true},
+		{"requirements-docker.txt", false},
+		{"requirements-special.txt", false},
+		{"requirements-prototype.txt", false},
+		{"requirements-prod.txt", false},
+		{"base.txt", false},
+	}
+	for _, tt := range tests {
+		if got := isDevRequirements(tt.base); got != tt.want {
+			t.Errorf("isDevRequirements(%q) = %v, want %v", tt.base, got, tt.want)
+		}
+	}
+}
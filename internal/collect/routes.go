@@ -0,0 +1,495 @@
+package collect
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+)
+
+// HTTPRoute é uma rota registrada no código Go.
+type HTTPRoute struct {
+	Method    string `json:"method"` // GET, POST... | ANY | MOUNT
+	Path      string `json:"path"`
+	Handler   string `json:"handler"`
+	Framework string `json:"framework"` // net/http | chi | gin | echo | fiber | gorilla
+	Func      string `json:"func,omitempty"`
+	Where     string `json:"where"` // arquivo:linha
+}
+
+// HTTPService agrupa as rotas alcançáveis a partir de um pacote main.
+type HTTPService struct {
+	Binary string      `json:"binary"` // diretório do pacote main ("" = rotas fora de qualquer binário)
+	Routes []HTTPRoute `json:"routes"`
+}
+
+// goRouteFacts são as rotas de um arquivo (paths ainda com ${...}).
+type goRouteFacts struct {
+	Dir    string
+	Routes []HTTPRoute
+}
+
+// routerLibs: prefixo do import -> framework.
+var routerLibs = []struct{ prefix, framework string }{
+	{"github.com/go-chi/chi", "chi"},
+	{"github.com/gin-gonic/gin", "gin"},
+	{"github.com/labstack/echo", "echo"},
+	{"github.com/gofiber/fiber", "fiber"},
+	{"github.com/gorilla/mux", "gorilla"},
+	{"net/http", "net/http"},
+}
+
+var (
+	routerConstructors = map[string]bool{"NewRouter": true, "NewMux": true, "New": true, "Default": true, "NewServeMux": true}
+	httpVerbs          = map[string]bool{"GET": true, "POST": true, "PUT": true, "PATCH": true, "DELETE": true,
+		"HEAD": true, "OPTIONS": true, "CONNECT": true, "TRACE": true}
+)
+
+// routerNode é um router/grupo dentro de um arquivo; o prefixo final é a
+// concatenação dos prefixos até a raiz.
+type routerNode struct {
+	parent    int
+	prefix    string
+	framework string
+}
+
+type routeWalker struct {
+	gs      *goSource
+	libs    map[string]string // nome local do import -> framework
+	deflt   string            // framework padrão do arquivo (lib de router importada)
+	nodes   []routerNode
+	handled map[*ast.CallExpr]bool
+	fn      string
+	consts  map[string]string
+	routes  []HTTPRoute
+	owners  []int // nó de cada rota
+}
+
+// routeFactsFromSource encontra registros de rotas em net/http (inclusive
+// padrões "GET /x" do Go 1.22), chi, gin, echo, fiber e gorilla/mux.
+// Prefixos de Route/Group/Mount/PathPrefix/StripPrefix são resolvidos quando
+// visíveis na mesma função.
+func routeFactsFromSource(gs *goSource, pkgConsts map[string]string) goRouteFacts {
+	facts := goRouteFacts{Dir: gs.Dir}
+	if gs.IsTest() {
+		return facts
+	}
+	w := &routeWalker{gs: gs, libs: map[string]string{}, handled: map[*ast.CallExpr]bool{}}
+	for p, name := range gs.Imports {
+		for _, lib := range routerLibs {
+			if p == lib.prefix || strings.HasPrefix(p, lib.prefix+"/") {
+				w.libs[name] = lib.framework
+				if w.deflt == "" || w.deflt == "net/http" {
+					w.deflt = lib.framework
+				}
+			}
+		}
+	}
+	if w.deflt == "" {
+		return facts
+	}
+	for _, d := range gs.File.Decls {
+		w.fn, w.consts = "", pkgConsts
+		if fd, ok := d.(*ast.FuncDecl); ok {
+			w.fn = funcDeclName(fd)
+			w.consts = gs.localStringConsts(fd.Body, pkgConsts)
+		}
+		w.walk(d, map[string]int{})
+	}
+	for i, r := range w.routes {
+		for n, depth := w.owners[i], 0; n >= 0 && depth < 20; n, depth = w.nodes[n].parent, depth+1 {
+			r.Path = joinRoute(w.nodes[n].prefix, r.Path)
+		}
+		if r.Path == "" {
+			r.Path = "/"
+		}
+		facts.Routes = append(facts.Routes, r)
+	}
+	return facts
+}
+
+func (w *routeWalker) walk(n ast.Node, env map[string]int) {
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range x.Lhs {
+				if i < len(x.Rhs) {
+					if id := w.nodeOf(x.Rhs[i], env); id >= 0 {
+						env[exprString(lhs)] = id
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range x.Names {
+				if i < len(x.Values) {
+					if id := w.nodeOf(x.Values[i], env); id >= 0 {
+						env[name.Name] = id
+					}
+				}
+			}
+		case *ast.CallExpr:
+			return w.call(x, env)
+		}
+		return true
+	})
+}
+
+func (w *routeWalker) newNode(parent int, prefix, framework string) int {
+	w.nodes = append(w.nodes, routerNode{parent: parent, prefix: prefix, framework: framework})
+	return len(w.nodes) - 1
+}
+
+// nodeOf reconhece expressões que produzem um router: construtores, variáveis
+// já conhecidas, Group("/p") (gin/echo/fiber), With(...) (chi) e
+// PathPrefix("/p").Subrouter() (gorilla).
+func (w *routeWalker) nodeOf(e ast.Expr, env map[string]int) int {
+	if id, ok := env[exprString(e)]; ok {
+		return id
+	}
+	call, ok := e.(*ast.CallExpr)
+	if !ok {
+		if u, ok := e.(*ast.UnaryExpr); ok {
+			return w.nodeOf(u.X, env)
+		}
+		return -1
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return -1
+	}
+	if pkg, ok := sel.X.(*ast.Ident); ok {
+		if fw, ok := w.libs[pkg.Name]; ok {
+			if routerConstructors[sel.Sel.Name] && (fw != "net/http" || sel.Sel.Name == "NewServeMux") {
+				return w.newNode(-1, "", fw)
+			}
+			return -1
+		}
+	}
+	switch sel.Sel.Name {
+	case "With":
+		return w.nodeOf(sel.X, env)
+	case "Group":
+		if len(call.Args) >= 1 {
+			if _, isFn := call.Args[0].(*ast.FuncLit); !isFn {
+				if parent := w.nodeOf(sel.X, env); parent >= 0 {
+					return w.newNode(parent, w.eval(call.Args[0]), w.nodes[parent].framework)
+				}
+			}
+		}
+	case "Subrouter":
+		inner, ok := sel.X.(*ast.CallExpr)
+		if !ok {
+			return -1
+		}
+		isel, ok := inner.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return -1
+		}
+		parent := w.nodeOf(isel.X, env)
+		if parent < 0 {
+			return -1
+		}
+		prefix := ""
+		if isel.Sel.Name == "PathPrefix" && len(inner.Args) == 1 {
+			prefix = w.eval(inner.Args[0])
+		}
+		return w.newNode(parent, prefix, "gorilla")
+	}
+	return -1
+}
+
+// call trata Route/Group com closure, Mount, StripPrefix e registros de rota.
+// Devolve false quando já percorreu os filhos por conta própria.
+func (w *routeWalker) call(x *ast.CallExpr, env map[string]int) bool {
+	sel, ok := x.Fun.(*ast.SelectorExpr)
+	if !ok || w.handled[x] {
+		return true
+	}
+	name, args := sel.Sel.Name, x.Args
+
+	switch {
+	case (name == "Route" && len(args) == 2) || (name == "Group" && len(args) == 1):
+		fl, ok := args[len(args)-1].(*ast.FuncLit)
+		if !ok || len(fl.Type.Params.List) == 0 || len(fl.Type.Params.List[0].Names) == 0 {
+			return true
+		}
+		parent, prefix := -1, ""
+		if name == "Route" {
+			parent, prefix = w.routerFor(sel.X, args[0], env), w.eval(args[0])
+		} else if parent = w.nodeOf(sel.X, env); parent < 0 {
+			// r.Group(func(r chi.Router) {...}) com r desconhecido: só pelo tipo do parâmetro
+			if pkg, _, ok := strings.Cut(exprString(fl.Type.Params.List[0].Type), "."); ok && w.libs[strings.TrimPrefix(pkg, "*")] != "" {
+				parent = w.lazyNode(sel.X, env)
+			}
+		}
+		if parent < 0 {
+			return true
+		}
+		child := make(map[string]int, len(env)+1)
+		for k, v := range env {
+			child[k] = v
+		}
+		child[fl.Type.Params.List[0].Names[0].Name] = w.newNode(parent, prefix, w.nodes[parent].framework)
+		w.walk(fl.Body, child)
+		return false
+	case name == "Mount" && len(args) == 2:
+		parent := w.routerFor(sel.X, args[0], env)
+		if parent < 0 {
+			return true
+		}
+		if sub, ok := env[exprString(args[1])]; ok && w.nodes[sub].parent < 0 && sub != parent {
+			w.nodes[sub].parent, w.nodes[sub].prefix = parent, joinRoute(w.eval(args[0]), w.nodes[sub].prefix)
+			return true
+		}
+		w.addRoute(x, parent, "MOUNT", w.eval(args[0]), args[1])
+		return true
+	case name == "Methods" && len(args) > 0:
+		// gorilla: r.HandleFunc("/x", h).Methods("GET", "POST")
+		inner, ok := sel.X.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		isel, ok := inner.Fun.(*ast.SelectorExpr)
+		if !ok || (isel.Sel.Name != "HandleFunc" && isel.Sel.Name != "Handle") || len(inner.Args) != 2 {
+			return true
+		}
+		var methods []string
+		for _, a := range args {
+			methods = append(methods, strings.ToUpper(w.eval(a)))
+		}
+		if node := w.routerFor(isel.X, inner.Args[0], env); node >= 0 {
+			w.handled[inner] = true
+			w.addRoute(inner, node, strings.Join(methods, ","), w.eval(inner.Args[0]), inner.Args[1])
+		}
+		return true
+	}
+
+	method, pathArg, ok := routeCall(name, args, w.eval)
+	if !ok {
+		return true
+	}
+	node := w.routerFor(sel.X, pathArg, env)
+	if node < 0 {
+		return true
+	}
+	p := w.eval(pathArg)
+	if method == "ANY" && w.nodes[node].framework == "net/http" {
+		// Go 1.22: "GET /users/{id}" (e opcionalmente host)
+		if m, rest, ok := strings.Cut(p, " "); ok && httpVerbs[m] {
+			method, p = m, strings.TrimSpace(rest)
+		}
+		if i := strings.Index(p, "/"); i > 0 {
+			p = p[i:]
+		}
+		// mux.Handle("/api/", http.StripPrefix("/api", sub))
+		if strip, ok := args[1].(*ast.CallExpr); ok && exprString(strip.Fun) == w.httpName()+".StripPrefix" && len(strip.Args) == 2 {
+			if sub, ok := env[exprString(strip.Args[1])]; ok && w.nodes[sub].parent < 0 && sub != node {
+				w.nodes[sub].parent, w.nodes[sub].prefix = node, w.eval(strip.Args[0])
+				return true
+			}
+		}
+	}
+	handler := args[len(args)-1]
+	if w.nodes[node].framework == "echo" {
+		// echo: handler logo após o path, middlewares depois
+		for i, a := range args[:len(args)-1] {
+			if a == pathArg {
+				handler = args[i+1]
+			}
+		}
+	}
+	w.addRoute(x, node, method, p, handler)
+	return true
+}
+
+// routeCall reconhece o método de registro e devolve o verbo HTTP e o argumento do path.
+func routeCall(name string, args []ast.Expr, eval func(ast.Expr) string) (string, ast.Expr, bool) {
+	if len(args) < 2 {
+		return "", nil, false
+	}
+	switch up := strings.ToUpper(name); {
+	case httpVerbs[name], httpVerbs[up] && name == strings.ToUpper(name[:1])+strings.ToLower(name[1:]):
+		return up, args[0], true // gin/echo GET, chi/fiber Get
+	case name == "Any" || name == "All":
+		return "ANY", args[0], true
+	case name == "Match" && len(args) >= 3: // echo: Match([]string{...}, path, h)
+		var methods []string
+		for _, m := range stringSliceElems(args[0]) {
+			methods = append(methods, eval(m))
+		}
+		return strings.Join(methods, ","), args[1], true
+	case name == "Handle" || name == "HandleFunc" || name == "Method" || name == "MethodFunc" || name == "Add":
+		if len(args) >= 3 {
+			if m := strings.ToUpper(eval(args[0])); httpVerbs[m] {
+				return m, args[1], true // gin Handle, chi Method, echo/fiber Add
+			}
+		}
+		if name == "Handle" || name == "HandleFunc" {
+			return "ANY", args[0], true
+		}
+	}
+	return "", nil, false
+}
+
+// routerFor devolve o nó do receptor; receptores desconhecidos (parâmetros,
+// campos de struct, http.HandleFunc) viram raízes quando o path é literal.
+func (w *routeWalker) routerFor(recv, pathArg ast.Expr, env map[string]int) int {
+	if id := w.nodeOf(recv, env); id >= 0 {
+		return id
+	}
+	lit, ok := pathArg.(*ast.BasicLit)
+	if !ok {
+		return -1
+	}
+	p := strings.Trim(lit.Value, "`\"")
+	if m, rest, ok := strings.Cut(p, " "); ok && httpVerbs[m] {
+		p = strings.TrimSpace(rest)
+	}
+	if !strings.HasPrefix(p, "/") {
+		return -1
+	}
+	return w.lazyNode(recv, env)
+}
+
+// lazyNode cria (uma vez por escopo) uma raiz para um receptor desconhecido.
+func (w *routeWalker) lazyNode(recv ast.Expr, env map[string]int) int {
+	if id := w.nodeOf(recv, env); id >= 0 {
+		return id
+	}
+	fw := w.deflt
+	if id, ok := recv.(*ast.Ident); ok && w.libs[id.Name] == "net/http" {
+		fw = "net/http" // http.HandleFunc -> DefaultServeMux
+	}
+	id := w.newNode(-1, "", fw)
+	env[exprString(recv)] = id
+	return id
+}
+
+func (w *routeWalker) httpName() string {
+	for name, fw := range w.libs {
+		if fw == "net/http" {
+			return name
+		}
+	}
+	return "http"
+}
+
+func (w *routeWalker) eval(e ast.Expr) string { return w.gs.evalString(e, w.consts) }
+
+func (w *routeWalker) addRoute(at ast.Node, node int, method, p string, handler ast.Expr) {
+	if c, ok := handler.(*ast.CallExpr); ok && strings.HasSuffix(exprString(c.Fun), ".HandlerFunc") && len(c.Args) == 1 {
+		handler = c.Args[0] // http.HandlerFunc(h)
+	}
+	h := exprString(handler)
+	if _, ok := handler.(*ast.FuncLit); ok {
+		h = "func literal"
+	}
+	w.routes = append(w.routes, HTTPRoute{
+		Method:    method,
+		Path:      p,
+		Handler:   h,
+		Framework: w.nodes[node].framework,
+		Func:      w.fn,
+		Where:     fmt.Sprintf("%s:%d", w.gs.Rel, w.gs.Line(at.Pos())),
+	})
+	w.owners = append(w.owners, node)
+}
+
+// joinRoute concatena prefixo e path sem barras duplicadas.
+func joinRoute(prefix, p string) string {
+	switch {
+	case prefix == "":
+		return p
+	case p == "":
+		return prefix
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(p, "/")
+}
+
+// buildHTTPEndpoints resolve constantes nos paths e atribui cada rota aos
+// binários (pacotes main) que importam, direta ou transitivamente, o pacote
+// em que ela é registrada.
+func buildHTTPEndpoints(facts []goRouteFacts, ix *goPackageIndex) []HTTPService {
+	byDir := map[string][]HTTPRoute{}
+	for _, f := range facts {
+		for _, r := range f.Routes {
+			r.Path = ix.resolve(r.Path, f.Dir)
+			byDir[f.Dir] = append(byDir[f.Dir], r)
+		}
+	}
+	if len(byDir) == 0 {
+		return nil
+	}
+
+	var out []HTTPService
+	reached := map[string]bool{}
+	for _, dir := range ix.mainDirs() {
+		var routes []HTTPRoute
+		for _, d := range ix.reachable(dir) {
+			if rs, ok := byDir[d]; ok {
+				routes = append(routes, rs...)
+				reached[d] = true
+			}
+		}
+		if len(routes) > 0 {
+			out = append(out, HTTPService{Binary: dir, Routes: routes})
+		}
+	}
+	var orphan []HTTPRoute
+	for d, rs := range byDir {
+		if !reached[d] {
+			orphan = append(orphan, rs...)
+		}
+	}
+	if len(orphan) > 0 {
+		out = append(out, HTTPService{Routes: orphan})
+	}
+	for i := range out {
+		rs := out[i].Routes
+		sort.Slice(rs, func(a, b int) bool {
+			if rs[a].Path != rs[b].Path {
+				return rs[a].Path < rs[b].Path
+			}
+			if rs[a].Method != rs[b].Method {
+				return rs[a].Method < rs[b].Method
+			}
+			return rs[a].Where < rs[b].Where
+		})
+	}
+	return out
+}
+
+// mainDirs lista os diretórios de pacotes main, ordenados.
+func (ix *goPackageIndex) mainDirs() []string {
+	var out []string
+	for dir, pkg := range ix.pkgs {
+		if pkg.Name == "main" {
+			out = append(out, dir)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// reachable devolve o diretório e todos os pacotes locais que ele importa
+// transitivamente, ordenados.
+func (ix *goPackageIndex) reachable(dir string) []string {
+	seen := map[string]bool{dir: true}
+	queue := []string{dir}
+	for len(queue) > 0 {
+		d := queue[0]
+		queue = queue[1:]
+		for imp := range ix.pkgs[d].Imports {
+			if dep := ix.importDir(imp); dep != "" && !seen[dep] {
+				seen[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for d := range seen {
+		out = append(out, d)
+	}
+	sort.Strings(out)
+	return out
+}
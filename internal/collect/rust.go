@@ -0,0 +1,279 @@
+package collect
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/files"
+)
+
+// RustCrate descreve um crate Cargo (um [package] de Cargo.toml).
+type RustCrate struct {
+	Dir               string   `json:"dir"`
+	Name              string   `json:"name"`
+	Version           string   `json:"version,omitempty"`
+	Edition           string   `json:"edition,omitempty"`
+	Workspace         string   `json:"workspace,omitempty"` // diretório do workspace ao qual pertence
+	Kinds             []string `json:"kinds,omitempty"`     // lib | bin | proc-macro
+	Bins              []string `json:"bins,omitempty"`
+	Features          []string `json:"features,omitempty"`
+	DefaultFeatures   []string `json:"default_features,omitempty"`
+	Dependencies      []string `json:"dependencies,omitempty"` // "serde 1", "core (path ../core)", "tokio (workspace)"
+	DevDependencies   []string `json:"dev_dependencies,omitempty"`
+	BuildDependencies []string `json:"build_dependencies,omitempty"`
+	Internal          []string `json:"internal,omitempty"` // crates do repo dos quais depende
+	Dependents        []string `json:"dependents,omitempty"`
+
+	hasLib, procMacro, inheritsEdition, inheritsVersion bool
+	pathDeps                                            map[string]string // nome -> diretório (relativo à raiz)
+	workspaceDeps                                       []string          // deps com workspace = true
+}
+
+// RustWorkspace descreve um [workspace] de Cargo.toml.
+type RustWorkspace struct {
+	Dir        string   `json:"dir"`
+	Members    []string `json:"members,omitempty"` // globs declarados
+	Exclude    []string `json:"exclude,omitempty"`
+	Crates     []string `json:"crates,omitempty"` // crates resolvidos
+	Resolver   string   `json:"resolver,omitempty"`
+	Edition    string   `json:"edition,omitempty"` // [workspace.package] edition
+	SharedDeps []string `json:"shared_deps,omitempty"`
+
+	version  string
+	pathDeps map[string]string // [workspace.dependencies] com path (relativo à raiz)
+}
+
+// parseCargoToml lê um Cargo.toml; pode conter um crate, um workspace ou ambos.
+func parseCargoToml(source *files.Source, full, rel string, maxBytes int64) (*RustCrate, *RustWorkspace, error) {
+	head, err := source.ReadHead(full, maxBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc := parseTOML(head)
+	dir := path.Dir(rel)
+
+	var ws *RustWorkspace
+	if w, ok := doc["workspace"].(map[string]any); ok {
+		ws = &RustWorkspace{Dir: dir}
+		ws.Members = toStrings(w["members"])
+		ws.Exclude = toStrings(w["exclude"])
+		ws.Resolver = settingString(w["resolver"])
+		ws.Edition = settingString(getPath(w, "package.edition"))
+		ws.version = settingString(getPath(w, "package.version"))
+		if deps, ok := w["dependencies"].(map[string]any); ok {
+			ws.SharedDeps = sortedKeys(deps)
+			ws.pathDeps = cargoPathDeps(dir, deps)
+		}
+	}
+
+	pkg, ok := doc["package"].(map[string]any)
+	if !ok {
+		return nil, ws, nil
+	}
+	rc := &RustCrate{Dir: dir, Name: settingString(pkg["name"])}
+	rc.Version, rc.inheritsVersion = cargoInheritable(pkg["version"])
+	rc.Edition, rc.inheritsEdition = cargoInheritable(pkg["edition"])
+	if rc.Edition == "" && !rc.inheritsEdition {
+		rc.Edition = "2015" // padrão do Cargo quando omitido
+	}
+
+	if lib, ok := doc["lib"].(map[string]any); ok {
+		rc.hasLib = true
+		rc.procMacro, _ = lib["proc-macro"].(bool)
+	}
+	if bins, ok := doc["bin"].([]any); ok {
+		for _, b := range bins {
+			if n := settingString(getPath(b, "name")); n != "" {
+				rc.Bins = append(rc.Bins, n)
+			}
+		}
+	}
+	if feats, ok := doc["features"].(map[string]any); ok {
+		for _, k := range sortedKeys(feats) {
+			if k == "default" {
+				rc.DefaultFeatures = toStrings(feats[k])
+				continue
+			}
+			rc.Features = append(rc.Features, k)
+		}
+	}
+
+	rc.pathDeps = map[string]string{}
+	addDeps := func(table any, dst *[]string) {
+		deps, ok := table.(map[string]any)
+		if !ok {
+			return
+		}
+		for name, spec := range deps {
+			desc, p, inWS := cargoDepSpec(spec)
+			*dst = append(*dst, strings.TrimSpace(name+" "+desc))
+			if p != "" {
+				rc.pathDeps[name] = path.Join(dir, p)
+			}
+			if inWS {
+				rc.workspaceDeps = append(rc.workspaceDeps, name)
+			}
+		}
+	}
+	addDeps(doc["dependencies"], &rc.Dependencies)
+	addDeps(doc["dev-dependencies"], &rc.DevDependencies)
+	addDeps(doc["build-dependencies"], &rc.BuildDependencies)
+	// [target.'cfg(unix)'.dependencies] etc.
+	if targets, ok := doc["target"].(map[string]any); ok {
+		for _, t := range targets {
+			addDeps(getPath(t, "dependencies"), &rc.Dependencies)
+			addDeps(getPath(t, "dev-dependencies"), &rc.DevDependencies)
+			addDeps(getPath(t, "build-dependencies"), &rc.BuildDependencies)
+		}
+	}
+	return rc, ws, nil
+}
+
+// cargoInheritable lê um campo que pode ser "x" ou { workspace = true }.
+func cargoInheritable(v any) (string, bool) {
+	if m, ok := v.(map[string]any); ok {
+		inherit, _ := m["workspace"].(bool)
+		return "", inherit
+	}
+	return settingString(v), false
+}
+
+// cargoDepSpec resume uma dependência: versão, (path x), (git x) ou (workspace).
+func cargoDepSpec(spec any) (desc, pathDep string, workspace bool) {
+	switch x := spec.(type) {
+	case string:
+		return x, "", false
+	case map[string]any:
+		if w, _ := x["workspace"].(bool); w {
+			return "(workspace)", "", true
+		}
+		if p := settingString(x["path"]); p != "" {
+			return "(path " + p + ")", p, false
+		}
+		if g := settingString(x["git"]); g != "" {
+			return "(git " + g + ")", "", false
+		}
+		return settingString(x["version"]), "", false
+	}
+	return "", "", false
+}
+
+func cargoPathDeps(dir string, deps map[string]any) map[string]string {
+	out := map[string]string{}
+	for name, spec := range deps {
+		if _, p, _ := cargoDepSpec(spec); p != "" {
+			out[name] = path.Join(dir, p)
+		}
+	}
+	return out
+}
+
+// consolidateRust resolve membros de workspaces, herança de edition/version,
+// o tipo de cada crate (pelos alvos declarados e src/lib.rs, src/main.rs,
+// src/bin/*.rs) e o grafo interno entre crates do repositório.
+func consolidateRust(crates []RustCrate, workspaces []RustWorkspace, paths []string) ([]RustCrate, []RustWorkspace) {
+	sort.Slice(crates, func(i, j int) bool { return crates[i].Dir < crates[j].Dir })
+	sort.Slice(workspaces, func(i, j int) bool { return workspaces[i].Dir < workspaces[j].Dir })
+	pathSet := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		pathSet[p] = true
+	}
+	byDir := map[string]int{}
+	for i, c := range crates {
+		byDir[c.Dir] = i
+	}
+
+	for wi := range workspaces {
+		ws := &workspaces[wi]
+		prefix := dirPrefix(ws.Dir)
+		for ci := range crates {
+			c := &crates[ci]
+			if c.Dir != ws.Dir {
+				if !strings.HasPrefix(c.Dir, prefix) {
+					continue
+				}
+				rel := strings.TrimPrefix(c.Dir, prefix)
+				if !matchWorkspaceGlobs(ws.Members, rel) || matchWorkspaceGlobs(ws.Exclude, rel) {
+					continue
+				}
+			}
+			c.Workspace = ws.Dir
+			ws.Crates = append(ws.Crates, c.Name)
+			if c.inheritsEdition {
+				c.Edition = ws.Edition
+			}
+			if c.inheritsVersion {
+				c.Version = ws.version
+			}
+			// workspace = true herda o path de [workspace.dependencies]
+			for _, d := range c.workspaceDeps {
+				if p, ok := ws.pathDeps[d]; ok {
+					c.pathDeps[d] = p
+				}
+			}
+		}
+		sort.Strings(ws.Crates)
+	}
+
+	for ci := range crates {
+		c := &crates[ci]
+		prefix := dirPrefix(c.Dir)
+		if c.procMacro {
+			c.Kinds = append(c.Kinds, "proc-macro")
+		} else if c.hasLib || pathSet[prefix+"src/lib.rs"] {
+			c.Kinds = append(c.Kinds, "lib")
+		}
+		if pathSet[prefix+"src/main.rs"] {
+			c.Bins = append(c.Bins, c.Name)
+		}
+		for _, p := range paths {
+			if rest, ok := strings.CutPrefix(p, prefix+"src/bin/"); ok {
+				name := strings.TrimSuffix(strings.TrimSuffix(rest, "/main.rs"), ".rs")
+				if !strings.Contains(name, "/") && name != rest {
+					c.Bins = append(c.Bins, name)
+				}
+			}
+		}
+		c.Bins = dedupeSorted(c.Bins)
+		if len(c.Bins) > 0 {
+			c.Kinds = append(c.Kinds, "bin")
+		}
+
+		var internal []string
+		for name, p := range c.pathDeps {
+			if ti, ok := byDir[p]; ok && ti != ci {
+				internal = append(internal, crates[ti].Name)
+			} else {
+				internal = append(internal, name) // path fora do scan: ainda é local
+			}
+		}
+		c.Internal = dedupeSorted(internal)
+		sort.Strings(c.Dependencies)
+		sort.Strings(c.DevDependencies)
+		sort.Strings(c.BuildDependencies)
+	}
+
+	byName := map[string]int{}
+	for i, c := range crates {
+		byName[c.Name] = i
+	}
+	for _, c := range crates {
+		for _, d := range c.Internal {
+			if ti, ok := byName[d]; ok {
+				crates[ti].Dependents = append(crates[ti].Dependents, c.Name)
+			}
+		}
+	}
+	for i := range crates {
+		crates[i].Dependents = dedupeSorted(crates[i].Dependents)
+	}
+	return crates, workspaces
+}
+
+func dirPrefix(dir string) string {
+	if dir == "." {
+		return ""
+	}
+	return dir + "/"
+}
@@ -0,0 +1,326 @@
+package collect
+
+import (
+	"go/ast"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TestInventory é o inventário estático de testes Go (independe de coverprofile).
+type TestInventory struct {
+	Packages    []PackageTests `json:"packages"`           // pacotes com ao menos um _test.go
+	Untested    []string       `json:"untested,omitempty"` // diretórios com .go mas sem _test.go
+	TestFiles   int            `json:"test_files"`
+	Tests       int            `json:"tests"`
+	Benchmarks  int            `json:"benchmarks"`
+	Fuzz        int            `json:"fuzz"`
+	Examples    int            `json:"examples"`
+	TableDriven int            `json:"table_driven"`
+}
+
+// PackageTests resume os testes de um diretório de pacote.
+type PackageTests struct {
+	Dir         string   `json:"dir"`
+	Package     string   `json:"package"`
+	TestFiles   int      `json:"test_files"`
+	Tests       int      `json:"tests"`
+	Benchmarks  int      `json:"benchmarks"`
+	Fuzz        int      `json:"fuzz"`
+	Examples    int      `json:"examples"`
+	TableDriven int      `json:"table_driven"`            // Test* com tabela de casos
+	ExternalPkg bool     `json:"external_pkg"`            // usa pacote "x_test" (caixa-preta)
+	HasTestdata bool     `json:"has_testdata"`            // diretório testdata/
+	FuzzCorpus  []string `json:"fuzz_corpus,omitempty"`   // alvos com testdata/fuzz/FuzzX/
+	UsesTestify bool     `json:"uses_testify"`            // github.com/stretchr/testify
+	UsesGomock  bool     `json:"uses_gomock"`             // go.uber.org/mock ou golang/mock
+	TestHelpers int      `json:"test_helpers,omitempty"`  // funções com t.Helper()
+	HasTestMain bool     `json:"has_test_main,omitempty"` // TestMain(m *testing.M)
+}
+
+// goTestFacts são os fatos de um único arquivo .go relevantes ao inventário.
+type goTestFacts struct {
+	Dir         string
+	Package     string
+	IsTest      bool
+	Tests       int
+	Benchmarks  int
+	Fuzz        int
+	Examples    int
+	TableDriven int
+	Helpers     int
+	TestMain    bool
+	Testify     bool
+	Gomock      bool
+}
+
+// analyzeGoTests extrai contagens de Test/Benchmark/Fuzz/Example de um _test.go.
+func analyzeGoTests(gs *goSource) goTestFacts {
+	tf := goTestFacts{Dir: gs.Dir, Package: gs.Package(), IsTest: gs.IsTest()}
+	if !tf.IsTest {
+		return tf
+	}
+	tf.Testify = gs.importsAny("github.com/stretchr/testify/")
+	tf.Gomock = gs.importsAny("github.com/golang/mock/", "go.uber.org/mock/")
+	for _, decl := range gs.File.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv != nil {
+			continue
+		}
+		name := fn.Name.Name
+		switch {
+		case name == "TestMain":
+			tf.TestMain = true
+		case isTestFuncName(name, "Test"):
+			tf.Tests++
+			if isTableDriven(fn) {
+				tf.TableDriven++
+			}
+		case isTestFuncName(name, "Benchmark"):
+			tf.Benchmarks++
+		case isTestFuncName(name, "Fuzz"):
+			tf.Fuzz++
+		case isTestFuncName(name, "Example"):
+			tf.Examples++
+		default:
+			if callsHelper(fn) {
+				tf.Helpers++
+			}
+		}
+	}
+	return tf
+}
+
+// isTestFuncName segue a regra do `go test`: prefixo seguido de fim ou de
+// caractere não minúsculo (TestFoo, Test_foo, Test; não Testfoo).
+func isTestFuncName(name, prefix string) bool {
+	if !strings.HasPrefix(name, prefix) {
+		return false
+	}
+	if len(name) == len(prefix) {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(name[len(prefix):])
+	return !unicode.IsLower(r)
+}
+
+// isTableDriven reconhece o padrão "tabela de casos": um range sobre um
+// composite literal de slice/map (direto ou via variável local) ou um range
+// cujo corpo chama t.Run.
+func isTableDriven(fn *ast.FuncDecl) bool {
+	if fn.Body == nil {
+		return false
+	}
+	tables := map[string]bool{}
+	found := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if found {
+			return false
+		}
+		switch x := n.(type) {
+		case *ast.AssignStmt:
+			for i, rhs := range x.Rhs {
+				if isCaseTable(rhs) && i < len(x.Lhs) {
+					if id, ok := x.Lhs[i].(*ast.Ident); ok {
+						tables[id.Name] = true
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for i, v := range x.Values {
+				if isCaseTable(v) && i < len(x.Names) {
+					tables[x.Names[i].Name] = true
+				}
+			}
+		case *ast.RangeStmt:
+			if isCaseTable(x.X) {
+				found = true
+				return false
+			}
+			if id, ok := x.X.(*ast.Ident); ok && tables[id.Name] {
+				found = true
+				return false
+			}
+			if callsTRun(x.Body) {
+				found = true
+				return false
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// isCaseTable: []struct{...}{...}, []T{...} com elementos compostos, map[...]struct{...}{...}.
+func isCaseTable(e ast.Expr) bool {
+	cl, ok := e.(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+	switch t := cl.Type.(type) {
+	case *ast.ArrayType:
+		if _, ok := t.Elt.(*ast.StructType); ok {
+			return true
+		}
+	case *ast.MapType:
+		if _, ok := t.Value.(*ast.StructType); ok {
+			return true
+		}
+	default:
+		return false
+	}
+	// []testCase{{...}, {...}}
+	for _, el := range cl.Elts {
+		if kv, ok := el.(*ast.KeyValueExpr); ok {
+			el = kv.Value
+		}
+		if _, ok := el.(*ast.CompositeLit); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func callsTRun(body *ast.BlockStmt) bool {
+	found := false
+	ast.Inspect(body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Run" {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+func callsHelper(fn *ast.FuncDecl) bool {
+	if fn.Body == nil {
+		return false
+	}
+	found := false
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			if sel, ok := call.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Helper" && len(call.Args) == 0 {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// buildTestInventory agrega os fatos por diretório e cruza com testdata/ e
+// corpora de fuzz encontrados no walk.
+func buildTestInventory(facts []goTestFacts, paths []string) *TestInventory {
+	if len(facts) == 0 {
+		return nil
+	}
+	type pkgAcc struct {
+		pt      PackageTests
+		hasCode bool
+	}
+	pkgs := map[string]*pkgAcc{}
+	get := func(dir string) *pkgAcc {
+		a, ok := pkgs[dir]
+		if !ok {
+			a = &pkgAcc{pt: PackageTests{Dir: dir}}
+			pkgs[dir] = a
+		}
+		return a
+	}
+	for _, f := range facts {
+		// arquivos dentro de testdata/ não são pacotes
+		if isUnderTestdata(f.Dir) {
+			continue
+		}
+		a := get(f.Dir)
+		if !f.IsTest {
+			a.hasCode = true
+			a.pt.Package = f.Package
+			continue
+		}
+		if a.pt.Package == "" && !strings.HasSuffix(f.Package, "_test") {
+			a.pt.Package = f.Package
+		}
+		if strings.HasSuffix(f.Package, "_test") {
+			a.pt.ExternalPkg = true
+		}
+		a.pt.TestFiles++
+		a.pt.Tests += f.Tests
+		a.pt.Benchmarks += f.Benchmarks
+		a.pt.Fuzz += f.Fuzz
+		a.pt.Examples += f.Examples
+		a.pt.TableDriven += f.TableDriven
+		a.pt.TestHelpers += f.Helpers
+		a.pt.HasTestMain = a.pt.HasTestMain || f.TestMain
+		a.pt.UsesTestify = a.pt.UsesTestify || f.Testify
+		a.pt.UsesGomock = a.pt.UsesGomock || f.Gomock
+	}
+
+	// testdata/ e testdata/fuzz/FuzzX/ (corpus do fuzzing nativo)
+	corpus := map[string]map[string]bool{}
+	for _, p := range paths {
+		q := "/" + p
+		i := strings.Index(q, "/testdata/")
+		if i < 0 {
+			continue
+		}
+		dir := strings.TrimPrefix(q[:i], "/")
+		if dir == "" {
+			dir = "."
+		}
+		rest := q[i+len("/testdata/"):]
+		if a, ok := pkgs[dir]; ok {
+			a.pt.HasTestdata = true
+			if strings.HasPrefix(rest, "fuzz/") {
+				parts := strings.SplitN(strings.TrimPrefix(rest, "fuzz/"), "/", 2)
+				if len(parts) == 2 && parts[0] != "" {
+					if corpus[dir] == nil {
+						corpus[dir] = map[string]bool{}
+					}
+					corpus[dir][parts[0]] = true
+				}
+			}
+		}
+	}
+
+	inv := &TestInventory{}
+	var dirs []string
+	for d := range pkgs {
+		dirs = append(dirs, d)
+	}
+	sort.Strings(dirs)
+	for _, d := range dirs {
+		a := pkgs[d]
+		if a.pt.TestFiles == 0 {
+			if a.hasCode {
+				inv.Untested = append(inv.Untested, d)
+			}
+			continue
+		}
+		for target := range corpus[d] {
+			a.pt.FuzzCorpus = append(a.pt.FuzzCorpus, target)
+		}
+		sort.Strings(a.pt.FuzzCorpus)
+		inv.Packages = append(inv.Packages, a.pt)
+		inv.TestFiles += a.pt.TestFiles
+		inv.Tests += a.pt.Tests
+		inv.Benchmarks += a.pt.Benchmarks
+		inv.Fuzz += a.pt.Fuzz
+		inv.Examples += a.pt.Examples
+		inv.TableDriven += a.pt.TableDriven
+	}
+	return inv
+}
+
+func isUnderTestdata(dir string) bool {
+	for _, seg := range strings.Split(path.Clean(dir), "/") {
+		if seg == "testdata" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package collect
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// parseTOML decodifica TOML (pyproject.toml, Pipfile, Cargo.toml, configs) em
+// map[string]any no mesmo formato do YAML/JSON: arrays de tabelas viram []any e
+// datas viram string. Um erro de sintaxe (ou um arquivo cortado por ReadHead)
+// não descarta o documento: fica o que foi lido até a linha anterior ao erro.
+func parseTOML(src string) map[string]any {
+	for {
+		var doc map[string]any
+		_, err := toml.Decode(src, &doc)
+		if err == nil {
+			return normalizeTOML(doc).(map[string]any)
+		}
+		var pe toml.ParseError
+		if !errors.As(err, &pe) || pe.Position.Line <= 1 {
+			return map[string]any{}
+		}
+		lines := strings.SplitAfter(src, "\n")
+		if pe.Position.Line-1 >= len(lines) {
+			return map[string]any{}
+		}
+		src = strings.Join(lines[:pe.Position.Line-1], "")
+	}
+}
+
+func normalizeTOML(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		if x == nil {
+			return map[string]any{}
+		}
+		for k, e := range x {
+			x[k] = normalizeTOML(e)
+		}
+		return x
+	case []map[string]any:
+		out := make([]any, len(x))
+		for i, e := range x {
+			out[i] = normalizeTOML(e)
+		}
+		return out
+	case []any:
+		for i, e := range x {
+			x[i] = normalizeTOML(e)
+		}
+		return x
+	case time.Time:
+		// datas sem fuso voltam ao formato em que foram escritas
+		switch x.Location().String() {
+		case "date-local":
+			return x.Format("2006-01-02")
+		case "time-local":
+			return x.Format("15:04:05.999999999")
+		case "datetime-local":
+			return x.Format("2006-01-02T15:04:05.999999999")
+		}
+		return x.Format(time.RFC3339Nano)
+	}
+	return v
+}
@@ -0,0 +1,130 @@
+package collect
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTOML(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want map[string]any
+	}{
+		{
+			name: "tabelas, chaves pontilhadas e tipos",
+			src: `# pyproject
+[project]
+name = "svc"
+requires-python = ">=3.11"
+dependencies = [
+  "fastapi>=0.110",  # comentário
+  'uvicorn',
+]
+tool.black.line-length = 100
+
+[project.optional-dependencies]
+dev = ["pytest"]
+
+[tool.poetry]
+packages = [{ include = "svc", from = "src" }]
+`,
+			want: map[string]any{
+				"project": map[string]any{
+					"name":            "svc",
+					"requires-python": ">=3.11",
+					"dependencies":    []any{"fastapi>=0.110", "uvicorn"},
+					"tool":            map[string]any{"black": map[string]any{"line-length": int64(100)}},
+					"optional-dependencies": map[string]any{
+						"dev": []any{"pytest"},
+					},
+				},
+				"tool": map[string]any{
+					"poetry": map[string]any{
+						"packages": []any{map[string]any{"include": "svc", "from": "src"}},
+					},
+				},
+			},
+		},
+		{
+			name: "arrays de tabelas viram []any",
+			src: `[package]
+name = "cli"
+edition = "2021"
+
+[[bin]]
+name = "a"
+path = "src/a.rs"
+
+[[bin]]
+name = "b"
+
+[dependencies]
+core = { path = "../core" }
+serde = { version = "1", features = ["derive"] }
+`,
+			want: map[string]any{
+				"package": map[string]any{"name": "cli", "edition": "2021"},
+				"bin": []any{
+					map[string]any{"name": "a", "path": "src/a.rs"},
+					map[string]any{"name": "b"},
+				},
+				"dependencies": map[string]any{
+					"core":  map[string]any{"path": "../core"},
+					"serde": map[string]any{"version": "1", "features": []any{"derive"}},
+				},
+			},
+		},
+		{
+			name: "strings multilinha, literais, bool, float e datas",
+			src: `desc = """
+linha 1
+linha 2"""
+re = 'C:\dir\*.txt'
+on = true
+ratio = 0.5
+big = 1_000
+day = 2024-03-01
+at = 1979-05-27T07:32:00Z
+local = 1979-05-27T07:32:00
+`,
+			want: map[string]any{
+				"desc":  "linha 1\nlinha 2",
+				"re":    `C:\dir\*.txt`,
+				"on":    true,
+				"ratio": 0.5,
+				"big":   int64(1000),
+				"day":   "2024-03-01",
+				"at":    "1979-05-27T07:32:00Z",
+				"local": "1979-05-27T07:32:00",
+			},
+		},
+		{
+			name: "erro de sintaxe mantém o que veio antes",
+			src: `[package]
+name = "x"
+version = "0.1.0"
+[dependencies]
+serde = { version = "1"
+`,
+			want: map[string]any{
+				"package":      map[string]any{"name": "x", "version": "0.1.0"},
+				"dependencies": map[string]any{},
+			},
+		},
+		{
+			name: "arquivo cortado no meio de uma string",
+			src:  "[packages]\nrequests = \"*\"\nflask = \"==3.",
+			want: map[string]any{"packages": map[string]any{"requests": "*"}},
+		},
+		{name: "vazio", src: "", want: map[string]any{}},
+		{name: "lixo", src: "=== not toml", want: map[string]any{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseTOML(tt.src); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTOML() = %#v\nwant %#v", got, tt.want)
+			}
+		})
+	}
+}
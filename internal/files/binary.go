@@ -0,0 +1,130 @@
+package files
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// magicNumbers são assinaturas de formatos binários comuns (executáveis,
+// imagens, arquivos compactados, bancos, fontes, pesos de modelos...) que já
+// trazem bytes fora do texto. Assinaturas só com ASCII ficam em textMagic.
+var magicNumbers = [][]byte{
+	[]byte("\x7fELF"),             // ELF
+	{0xfe, 0xed, 0xfa, 0xce},      // Mach-O 32
+	{0xfe, 0xed, 0xfa, 0xcf},      // Mach-O 64
+	{0xce, 0xfa, 0xed, 0xfe},      // Mach-O 32 (LE)
+	{0xcf, 0xfa, 0xed, 0xfe},      // Mach-O 64 (LE)
+	{0xca, 0xfe, 0xba, 0xbe},      // Mach-O universal / Java class
+	[]byte("\x00asm"),             // WebAssembly
+	[]byte("\x89PNG"),             // PNG
+	{0xff, 0xd8, 0xff},            // JPEG
+	[]byte("%PDF-"),               // PDF
+	[]byte("PK\x03\x04"),          // zip, jar, docx...
+	{0x1f, 0x8b},                  // gzip
+	[]byte("7z\xbc\xaf\x27\x1c"),  // 7z
+	[]byte("\xfd7zXZ\x00"),        // xz
+	{0x28, 0xb5, 0x2f, 0xfd},      // zstd
+	[]byte("SQLite format 3\x00"), // SQLite
+	{0x00, 0x01, 0x00, 0x00},      // TrueType
+	[]byte("\x89HDF"),             // HDF5 (.h5)
+	[]byte("\x93NUMPY"),           // .npy
+	[]byte("!<arch>\n"),           // .a, .deb
+	{0xed, 0xab, 0xee, 0xdb},      // rpm
+}
+
+// LooksBinary decide, pelos primeiros bytes, se o conteúdo é binário: números
+// mágicos conhecidos (os só ASCII com o cabeçalho conferido), byte NUL (exceto texto UTF-16 com BOM) ou proporção alta
+// de UTF-8 inválido e caracteres de controle.
+func LooksBinary(head []byte) bool {
+	if len(head) == 0 {
+		return false
+	}
+	for _, m := range magicNumbers {
+		if bytes.HasPrefix(head, m) {
+			return true
+		}
+	}
+	if textMagic(head) {
+		return true
+	}
+	if len(head) >= 8 && head[0] == 0 && string(head[4:8]) == "ftyp" { // mp4, mov, heic (tamanho BE pequeno)
+		return true
+	}
+	if bytes.HasPrefix(head, []byte{0xff, 0xfe}) || bytes.HasPrefix(head, []byte{0xfe, 0xff}) {
+		return false // UTF-16 com BOM
+	}
+	return binaryContent(head)
+}
+
+// textMagic reconhece formatos cuja assinatura é só ASCII ("RIFF", "ID3",
+// "GGUF"...): um NOTES.txt pode começar com as mesmas letras, então exige o
+// resto do cabeçalho estruturado ou, sem campos fixos, que o conteúdo após a
+// assinatura também pareça binário.
+func textMagic(h []byte) bool {
+	at := func(i int, vals ...string) bool {
+		for _, v := range vals {
+			if len(h) >= i+len(v) && string(h[i:i+len(v)]) == v {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case at(0, "RIFF"): // tamanho (4 bytes) + tipo
+		return at(8, "WAVE", "WEBP", "AVI ")
+	case at(0, "ID3"): // versão 2.2–2.4, revisão 0 e tamanho syncsafe
+		return len(h) >= 10 && h[3] >= 2 && h[3] <= 4 && h[4] == 0 &&
+			h[6] < 0x80 && h[7] < 0x80 && h[8] < 0x80 && h[9] < 0x80
+	case at(0, "BZh"): // nível 1–9 + bloco ou fim de stream
+		return len(h) >= 4 && h[3] >= '1' && h[3] <= '9' && at(4, "1AY&SY", "\x17rE8P\x90")
+	case at(0, "OggS"): // versão 0
+		return at(4, "\x00")
+	case at(0, "GGUF"): // versão uint32 LE
+		return len(h) >= 8 && h[4] >= 1 && h[4] <= 3 && at(5, "\x00\x00\x00")
+	case at(0, "wOFF", "wOF2"): // flavor sfnt
+		return at(4, "\x00\x01\x00\x00", "OTTO", "true")
+	case at(0, "OTTO"): // numTables uint16 BE
+		return len(h) >= 6 && h[4] == 0 && h[5] > 0
+	case at(0, "GIF87a", "GIF89a"):
+		return binaryContent(h[6:])
+	case at(0, "PAR1"):
+		return binaryContent(h[4:])
+	}
+	return false
+}
+
+// binaryContent aplica a heurística de conteúdo: byte NUL ou proporção alta
+// de UTF-8 inválido e caracteres de controle.
+func binaryContent(head []byte) bool {
+	if bytes.IndexByte(head, 0) >= 0 {
+		return true
+	}
+	bad := 0
+	for i := 0; i < len(head); {
+		r, size := utf8.DecodeRune(head[i:])
+		switch {
+		case r == utf8.RuneError && size <= 1:
+			if len(head)-i < utf8.UTFMax {
+				i = len(head) // rune cortada no fim do trecho lido
+				continue
+			}
+			bad++
+		case r < 0x20 && r != '\t' && r != '\n' && r != '\r' && r != '\f' && r != '\b' && r != 0x1b:
+			bad++
+		}
+		i += size
+	}
+	return bad*10 > len(head)*3
+}
+
+// IsBinary lê o início do arquivo e aplica LooksBinary.
+func (s *Source) IsBinary(path string) (bool, error) {
+	f, err := s.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = f.Close() }()
+	buf := make([]byte, 4096)
+	n, _ := f.Read(buf)
+	return LooksBinary(buf[:n]), nil
+}
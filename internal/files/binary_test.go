@@ -0,0 +1,55 @@
+package files
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLooksBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		head string
+		want bool
+	}{
+		// falsos positivos: texto que começa com uma assinatura ASCII
+		{"notas sobre ID3", "ID3 tag notes for the audio pipeline\n", false},
+		{"notas sobre RIFF", "RIFF chunks: WAVE and WEBP share the container\n", false},
+		{"texto GGUF", "GGUF loader TODO: support v3\n", false},
+		{"texto PAR1", "PAR1 is the parquet magic\n", false},
+		{"texto BZh", "BZh9 means block size 900k\n", false},
+		{"texto OTTO", "OTTO fonts are CFF-flavoured\n", false},
+		{"texto wOFF", "wOFF header layout\n", false},
+		{"texto GIF89a", "GIF89a decoder notes\n", false},
+		{"texto OggS", "OggS pages are 4 KiB\n", false},
+		{"texto ftyp", "abcdftyp is the mp4 box\n", false},
+		{"texto UTF-8", "olá, ação — 日本語\n", false},
+		{"UTF-16 com BOM", "\xff\xfeh\x00i\x00", false},
+		{"vazio", "", false},
+
+		// verdadeiros positivos
+		{"wav", "RIFF\x24\x08\x00\x00WAVEfmt ", true},
+		{"webp", "RIFF\xff\xff\xff\x7fWEBPVP8 ", true},
+		{"avi", "RIFF\x10\x00\x00\x00AVI LIST", true},
+		{"mp3", "ID3\x04\x00\x00\x00\x00\x1f\x76TIT2", true},
+		{"bzip2", "BZh91AY&SY\x8e\x02", true},
+		{"ogg", "OggS\x00\x02\x00\x00", true},
+		{"gguf", "GGUF\x03\x00\x00\x00\x22\x01", true},
+		{"woff", "wOFF\x00\x01\x00\x00\x00\x00", true},
+		{"woff2 OTTO", "wOF2OTTO\x00\x00", true},
+		{"otf", "OTTO\x00\x0b\x00\x80", true},
+		{"gif", "GIF89a\x10\x00\x10\x00\x80\x00\x00", true},
+		{"parquet", "PAR1\x15\x04\x15\x00", true},
+		{"png", "\x89PNG\r\n\x1a\n", true},
+		{"elf", "\x7fELF\x02\x01\x01", true},
+		{"mp4", "\x00\x00\x00\x18ftypmp42", true},
+		{"NUL no meio", "abc\x00def", true},
+		{"controle demais", strings.Repeat("\x01\x02\x03a", 20), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LooksBinary([]byte(tt.head)); got != tt.want {
+				t.Errorf("LooksBinary(%q) = %v, want %v", tt.head, got, tt.want)
+			}
+		})
+	}
+}
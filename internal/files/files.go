@@ -5,12 +5,12 @@ import (
 	"bufio"
 	"errors"
 	"io"
-	"os"
 	"path/filepath"
 	"strings"
 )
 
 // DefaultIgnore returns common directories/files to skip for LLM-oriented scans.
+// Binários são detectados pelo conteúdo (ver LooksBinary), não pela extensão.
 func DefaultIgnore() []string {
 	return []string{
 		".git/", ".git/**",
@@ -19,17 +19,13 @@ func DefaultIgnore() []string {
 		".venv/**", "venv/**", "__pycache__/**",
 		".idea/**", ".vscode/**", ".DS_Store",
 		".terraform/**", "vendor/**",
-		"*.png", "*.jpg", "*.jpeg", "*.gif", "*.webp", "*.ico",
-		"*.pdf", "*.ppt", "*.pptx", "*.doc", "*.docx", "*.xls", "*.xlsx",
-		"*.zip", "*.tar", "*.gz", "*.tgz", "*.7z",
-		"*.mp4", "*.mp3", "*.mov", "*.avi",
 	}
 }
 
 // ReadHead lê até maxBytes do início do arquivo (head) e retorna como string.
 // Útil para limitação de contexto em arquivos grandes.
-func ReadHead(path string, maxBytes int64) (string, error) {
-	f, err := os.Open(path)
+func (s *Source) ReadHead(path string, maxBytes int64) (string, error) {
+	f, err := s.Open(path)
 	if err != nil {
 		return "", err
 	}
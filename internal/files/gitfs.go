@@ -0,0 +1,314 @@
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os/exec"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GitFS é um fs.FS somente leitura com a árvore de um commit, lida direto do
+// banco de objetos do repositório local (git ls-tree / git cat-file), sem
+// checkout — funciona também em clones bare. Submódulos e symlinks ficam de fora.
+type GitFS struct {
+	Commit string    // hash completo do commit
+	Time   time.Time // data do commit
+
+	ctx   context.Context
+	dir   string
+	files map[string]gitEntry
+	dirs  map[string][]string // diretório -> nomes dos filhos (ordenados)
+
+	mu       sync.Mutex // protege o processo "git cat-file --batch"
+	batch    *exec.Cmd
+	batchIn  io.WriteCloser
+	batchOut *bufio.Reader
+}
+
+type gitEntry struct {
+	oid  string
+	size int64
+	mode fs.FileMode
+}
+
+// blobs maiores que isso são lidos em streaming por um "git cat-file blob" próprio.
+const gitBatchMax = 1 << 20
+
+// NewGitFS resolve rev para um commit e indexa a árvore dele. Se dir for um
+// subdiretório do working tree, a árvore é a desse subdiretório.
+func NewGitFS(ctx context.Context, dir, rev string) (*GitFS, error) {
+	if rev == "" || strings.HasPrefix(rev, "-") {
+		return nil, fmt.Errorf("invalid revision %q", rev)
+	}
+	out, err := runGit(ctx, dir, "rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	if err != nil {
+		return nil, fmt.Errorf("resolve revision %q: %w", rev, err)
+	}
+	g := &GitFS{
+		Commit: strings.TrimSpace(string(out)),
+		ctx:    ctx,
+		dir:    dir,
+		files:  map[string]gitEntry{},
+		dirs:   map[string][]string{".": nil},
+	}
+	if out, err := runGit(ctx, dir, "show", "-s", "--format=%ct", g.Commit); err == nil {
+		if ts, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64); err == nil {
+			g.Time = time.Unix(ts, 0).UTC()
+		}
+	}
+	prefix := ""
+	if out, err := runGit(ctx, dir, "rev-parse", "--show-prefix"); err == nil {
+		prefix = strings.TrimSpace(string(out)) // vazio na raiz e em repositórios bare
+	}
+	out, err = runGit(ctx, dir, "ls-tree", "-r", "-z", "--long", "--full-tree", g.Commit)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for _, rec := range bytes.Split(out, []byte{0}) {
+		// "<mode> <type> <oid> <size>\t<path>"
+		meta, p, ok := strings.Cut(string(rec), "\t")
+		if !ok || !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		f := strings.Fields(meta)
+		if len(f) != 4 || f[1] != "blob" || f[0] == "120000" {
+			continue
+		}
+		size, _ := strconv.ParseInt(f[3], 10, 64)
+		mode := fs.FileMode(0o644)
+		if f[0] == "100755" {
+			mode = 0o755
+		}
+		p = strings.TrimPrefix(p, prefix)
+		g.files[p] = gitEntry{oid: f[2], size: size, mode: mode}
+		for child, parent := p, path.Dir(p); ; child, parent = parent, path.Dir(parent) {
+			if seen[child] {
+				break
+			}
+			seen[child] = true
+			g.dirs[parent] = append(g.dirs[parent], path.Base(child))
+			if parent == "." {
+				break
+			}
+		}
+	}
+	for d := range g.dirs {
+		sort.Strings(g.dirs[d])
+	}
+	return g, nil
+}
+
+// Close encerra o processo de leitura em lote.
+func (g *GitFS) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.batch == nil {
+		return nil
+	}
+	_ = g.batchIn.Close()
+	err := g.batch.Wait()
+	g.batch = nil
+	return err
+}
+
+// Open implementa fs.FS.
+func (g *GitFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if _, ok := g.dirs[name]; ok {
+		entries, _ := g.ReadDir(name)
+		return &gitDir{info: g.dirInfo(name), entries: entries}, nil
+	}
+	e, ok := g.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	info := gitFileInfo{name: path.Base(name), size: e.size, mode: e.mode, mtime: g.Time}
+	if e.size > gitBatchMax {
+		return g.openStream(name, e.oid, info)
+	}
+	data, err := g.readBlob(e.oid)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &gitFile{info: info, r: bytes.NewReader(data)}, nil
+}
+
+// Stat implementa fs.StatFS sem ler o conteúdo.
+func (g *GitFS) Stat(name string) (fs.FileInfo, error) {
+	if _, ok := g.dirs[name]; ok {
+		return g.dirInfo(name), nil
+	}
+	if e, ok := g.files[name]; ok {
+		return gitFileInfo{name: path.Base(name), size: e.size, mode: e.mode, mtime: g.Time}, nil
+	}
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadDir implementa fs.ReadDirFS.
+func (g *GitFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	children, ok := g.dirs[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	entries := make([]fs.DirEntry, 0, len(children))
+	for _, c := range children {
+		p := c
+		if name != "." {
+			p = name + "/" + c
+		}
+		info, err := g.Stat(p)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, fs.FileInfoToDirEntry(info))
+	}
+	return entries, nil
+}
+
+func (g *GitFS) dirInfo(name string) gitFileInfo {
+	return gitFileInfo{name: path.Base(name), mode: fs.ModeDir | 0o755, mtime: g.Time}
+}
+
+// readBlob lê um blob pelo "git cat-file --batch", iniciado na primeira leitura.
+func (g *GitFS) readBlob(oid string) ([]byte, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.batch == nil {
+		cmd := exec.CommandContext(g.ctx, "git", "-C", g.dir, "cat-file", "--batch")
+		in, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, err
+		}
+		out, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, err
+		}
+		g.batch, g.batchIn, g.batchOut = cmd, in, bufio.NewReader(out)
+	}
+	if _, err := fmt.Fprintln(g.batchIn, oid); err != nil {
+		return nil, err
+	}
+	// "<oid> blob <size>\n<conteúdo>\n" ou "<oid> missing\n"
+	header, err := g.batchOut.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	f := strings.Fields(header)
+	if len(f) != 3 {
+		return nil, fmt.Errorf("git cat-file: %s", strings.TrimSpace(header))
+	}
+	size, err := strconv.ParseInt(f[2], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	buf := make([]byte, size+1)
+	if _, err := io.ReadFull(g.batchOut, buf); err != nil {
+		return nil, err
+	}
+	return buf[:size], nil
+}
+
+// openStream abre um blob grande sem carregá-lo inteiro na memória.
+func (g *GitFS) openStream(name, oid string, info gitFileInfo) (fs.File, error) {
+	cmd := exec.CommandContext(g.ctx, "git", "-C", g.dir, "cat-file", "blob", oid)
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	return &gitFile{info: info, r: out, close: func() error {
+		_ = out.Close()
+		_ = cmd.Wait() // leitura parcial encerra com SIGPIPE; não é erro para quem leu
+		return nil
+	}}, nil
+}
+
+func runGit(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, errors.New(msg)
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+type gitFileInfo struct {
+	name  string
+	size  int64
+	mode  fs.FileMode
+	mtime time.Time
+}
+
+func (i gitFileInfo) Name() string       { return i.name }
+func (i gitFileInfo) Size() int64        { return i.size }
+func (i gitFileInfo) Mode() fs.FileMode  { return i.mode }
+func (i gitFileInfo) ModTime() time.Time { return i.mtime }
+func (i gitFileInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i gitFileInfo) Sys() any           { return nil }
+
+type gitFile struct {
+	info  gitFileInfo
+	r     io.Reader
+	close func() error
+}
+
+func (f *gitFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *gitFile) Read(p []byte) (int, error) { return f.r.Read(p) }
+func (f *gitFile) Close() error {
+	if f.close != nil {
+		return f.close()
+	}
+	return nil
+}
+
+type gitDir struct {
+	info    gitFileInfo
+	entries []fs.DirEntry
+	off     int
+}
+
+func (d *gitDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *gitDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: errors.New("is a directory")}
+}
+func (d *gitDir) Close() error { return nil }
+
+// ReadDir implementa fs.ReadDirFile.
+func (d *gitDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.off:]
+	if n <= 0 {
+		d.off = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.off += n
+	return rest[:n], nil
+}
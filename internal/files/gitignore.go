@@ -1,8 +1,7 @@
 package files
 
 import (
-	"os"
-	"path/filepath"
+	"io/fs"
 	"strings"
 
 	ignore "github.com/sabhiram/go-gitignore"
@@ -13,16 +12,16 @@ type GitIgnoreMatcher struct {
 	ignores []*ignore.GitIgnore
 }
 
-// NewGitIgnoreMatcher lê todos os .gitignore encontrados até a raiz.
-func NewGitIgnoreMatcher(root string) *GitIgnoreMatcher {
+// NewGitIgnoreMatcher lê todos os .gitignore encontrados até a raiz (em source).
+func NewGitIgnoreMatcher(source *Source, root string) *GitIgnoreMatcher {
 	var patterns []*ignore.GitIgnore
-	_ = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+	_ = source.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return nil
 		}
-		if strings.HasSuffix(info.Name(), ".gitignore") {
-			if g, e := ignore.CompileIgnoreFile(path); e == nil {
-				patterns = append(patterns, g)
+		if strings.HasSuffix(d.Name(), ".gitignore") {
+			if data, e := source.ReadFile(path); e == nil {
+				patterns = append(patterns, ignore.CompileIgnoreLines(strings.Split(string(data), "\n")...))
 			}
 		}
 		return nil
@@ -0,0 +1,81 @@
+package files
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Source é de onde uma varredura lê seus arquivos. Os caminhos continuam
+// absolutos sob a raiz; com um fs.FS (por exemplo, o snapshot de uma revisão
+// git — ver GitFS), os que ficam sob root são lidos dele. Um *Source nil lê do
+// disco. Cada varredura tem o seu, então varreduras concorrentes não se misturam.
+type Source struct {
+	root string
+	fsys fs.FS
+}
+
+// NewSource faz as leituras de caminhos sob root irem para fsys (nil lê do disco).
+func NewSource(root string, fsys fs.FS) *Source {
+	return &Source{root: root, fsys: fsys}
+}
+
+// inSource devolve o caminho relativo (com "/") dentro de fsys.
+func (s *Source) inSource(path string) (string, bool) {
+	if s == nil || s.fsys == nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(s.root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(rel), true
+}
+
+// Open abre um arquivo da fonte.
+func (s *Source) Open(path string) (fs.File, error) {
+	if rel, ok := s.inSource(path); ok {
+		return s.fsys.Open(rel)
+	}
+	return os.Open(path)
+}
+
+// ReadFile lê um arquivo inteiro da fonte.
+func (s *Source) ReadFile(path string) ([]byte, error) {
+	if rel, ok := s.inSource(path); ok {
+		return fs.ReadFile(s.fsys, rel)
+	}
+	return os.ReadFile(path)
+}
+
+// Stat descreve um arquivo da fonte.
+func (s *Source) Stat(path string) (fs.FileInfo, error) {
+	if rel, ok := s.inSource(path); ok {
+		return fs.Stat(s.fsys, rel)
+	}
+	return os.Stat(path)
+}
+
+// ReadDir lista um diretório da fonte.
+func (s *Source) ReadDir(path string) ([]fs.DirEntry, error) {
+	if rel, ok := s.inSource(path); ok {
+		return fs.ReadDir(s.fsys, rel)
+	}
+	return os.ReadDir(path)
+}
+
+// WalkDir percorre root na fonte, passando a fn caminhos no formato de
+// filepath.WalkDir (root + caminho relativo).
+func (s *Source) WalkDir(root string, fn fs.WalkDirFunc) error {
+	rel, ok := s.inSource(root)
+	if !ok {
+		return filepath.WalkDir(root, fn)
+	}
+	return fs.WalkDir(s.fsys, rel, func(p string, d fs.DirEntry, err error) error {
+		if r, e := filepath.Rel(rel, p); e == nil {
+			p = r
+		}
+		return fn(filepath.Join(root, filepath.FromSlash(p)), d, err)
+	})
+}
@@ -0,0 +1,60 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeRESTAPIs renderiza a seção "REST APIs" (OpenAPI 3 / Swagger 2).
+func writeRESTAPIs(b *bytes.Buffer, specs []collect.APISpec) {
+	if len(specs) == 0 {
+		return
+	}
+	b.WriteString("## REST APIs\n\n")
+	for _, s := range specs {
+		if s.Unparsed != "" {
+			b.WriteString(fmt.Sprintf("- `%s` — _%s spec truncated/unparsed: %s_\n", s.File, s.Format, s.Unparsed))
+			continue
+		}
+		title := s.Title
+		if title == "" {
+			title = "(untitled)"
+		}
+		if s.Version != "" {
+			title += " " + s.Version
+		}
+		b.WriteString(fmt.Sprintf("- `%s` — **%s** (%s, %d operations)\n", s.File, title, s.Format, len(s.Operations)))
+		if len(s.Servers) > 0 {
+			b.WriteString("  - servers: " + joinList(s.Servers, 5, ", ") + "\n")
+		}
+		if len(s.RefFiles) > 0 {
+			b.WriteString("  - $ref files: " + joinList(s.RefFiles, 10, ", ") + "\n")
+		}
+	}
+	b.WriteString("\n")
+
+	for _, s := range specs {
+		if len(s.Operations) == 0 {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("**%s**\n\n", s.File))
+		b.WriteString("| Method | Path | operationId | Summary | Tags | Request | Responses |\n")
+		b.WriteString("|---|---|---|---|---|---|---|\n")
+		ops := s.Operations
+		if len(ops) > 80 {
+			ops = ops[:80]
+		}
+		for _, o := range ops {
+			b.WriteString(fmt.Sprintf("| %s | `%s` | %s | %s | %s | %s | %s |\n",
+				o.Method, o.Path, o.OperationID, strings.ReplaceAll(o.Summary, "|", "\\|"),
+				strings.Join(o.Tags, ", "), o.Request, strings.Join(o.Responses, ", ")))
+		}
+		if n := len(s.Operations) - len(ops); n > 0 {
+			b.WriteString(fmt.Sprintf("| … (%d more) | | | | | | |\n", n))
+		}
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,168 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeBehaviorCatalog renderiza os .feature como catálogo de comportamento,
+// agrupado por capacidade (diretório) e por tag.
+func writeBehaviorCatalog(b *bytes.Buffer, specs []collect.GherkinFeature) {
+	if len(specs) == 0 {
+		return
+	}
+	b.WriteString("## Behavior Catalog\n\n")
+
+	// Por capacidade
+	byCap := map[string][]collect.GherkinFeature{}
+	var caps []string
+	for _, f := range specs {
+		if _, ok := byCap[f.Capability]; !ok {
+			caps = append(caps, f.Capability)
+		}
+		byCap[f.Capability] = append(byCap[f.Capability], f)
+	}
+	sort.Strings(caps)
+	const maxScenarios = 200
+	shown := 0
+	for _, c := range caps {
+		b.WriteString(fmt.Sprintf("### %s\n\n", c))
+		for _, f := range byCap[c] {
+			name := f.Name
+			if name == "" {
+				name = "(no title)"
+			}
+			line := fmt.Sprintf("- **%s** — `%s`", name, f.File)
+			if len(f.Tags) > 0 {
+				line += " " + strings.Join(f.Tags, " ")
+			}
+			b.WriteString(line + "\n")
+			if f.Description != "" {
+				b.WriteString("  - " + f.Description + "\n")
+			}
+			if f.HasBackground {
+				b.WriteString("  - _(background)_\n")
+			}
+			for _, sc := range f.Scenarios {
+				if shown >= maxScenarios {
+					break
+				}
+				shown++
+				s := "  - " + sc.Name
+				if sc.Rule != "" {
+					s = "  - [" + sc.Rule + "] " + sc.Name
+				}
+				if sc.Kind == "outline" {
+					s += fmt.Sprintf(" _(outline × %d examples)_", sc.Examples)
+				}
+				if len(sc.Tags) > 0 {
+					s += " " + strings.Join(sc.Tags, " ")
+				}
+				b.WriteString(s + "\n")
+			}
+		}
+		b.WriteString("\n")
+	}
+	if total := countScenarios(specs); total > shown {
+		b.WriteString(fmt.Sprintf("_… %d more scenarios omitted._\n\n", total-shown))
+	}
+
+	// Por tag (com herança feature → rule → cenário)
+	byTag := map[string][]string{}
+	untagged := 0
+	for i := range specs {
+		f := &specs[i]
+		for _, sc := range f.Scenarios {
+			tags := uniqueSorted(f.EffectiveTags(sc))
+			if len(tags) == 0 {
+				untagged++
+				continue
+			}
+			for _, t := range tags {
+				byTag[t] = append(byTag[t], f.Name+" › "+sc.Name)
+			}
+		}
+	}
+	if len(byTag) > 0 {
+		b.WriteString("**By tag**\n\n")
+		var tags []string
+		for t := range byTag {
+			tags = append(tags, t)
+		}
+		sort.Strings(tags)
+		for _, t := range tags {
+			x := byTag[t]
+			n := len(x)
+			if len(x) > 8 {
+				x = append(x[:8:8], "…")
+			}
+			b.WriteString(fmt.Sprintf("- `%s` (%d): %s\n", t, n, strings.Join(x, "; ")))
+		}
+		if untagged > 0 {
+			b.WriteString(fmt.Sprintf("- _(untagged)_ (%d)\n", untagged))
+		}
+		b.WriteString("\n")
+	}
+}
+
+func countScenarios(specs []collect.GherkinFeature) int {
+	n := 0
+	for _, f := range specs {
+		n += len(f.Scenarios)
+	}
+	return n
+}
+
+// writeBDDResults renderiza status Cucumber por feature, cenários falhos e o
+// cruzamento passos Gherkin × step definitions godog.
+func writeBDDResults(b *bytes.Buffer, bdd *collect.BDDSum) {
+	if len(bdd.FeatureResults) == 0 && len(bdd.UndefinedSteps) == 0 && len(bdd.UnusedStepDefs) == 0 {
+		return
+	}
+	b.WriteString("## BDD Results\n\n")
+	if len(bdd.FeatureResults) > 0 {
+		b.WriteString("| Feature | Scenarios | Passed | Failed | Skipped | Pending | Undefined | Seconds |\n")
+		b.WriteString("|---|---:|---:|---:|---:|---:|---:|---:|\n")
+		for _, f := range bdd.FeatureResults {
+			b.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d | %d | %d | %.2f |\n",
+				f.Name, f.Scenarios, f.Passed, f.Failed, f.Skipped, f.Pending, f.Undefined, f.Seconds))
+		}
+		b.WriteString("\n")
+	}
+	if len(bdd.FailingScenarios) > 0 {
+		b.WriteString("**Failing scenarios**\n\n")
+		for _, s := range bdd.FailingScenarios {
+			line := fmt.Sprintf("- %s › %s — `%s`", s.Feature, s.Scenario, s.Step)
+			if s.Message != "" {
+				line += ": " + s.Message
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+	if len(bdd.UndefinedSteps) > 0 {
+		b.WriteString("**Undefined steps** (no matching godog step definition)\n\n")
+		x := bdd.UndefinedSteps
+		if len(x) > 40 {
+			x = x[:40]
+		}
+		for _, u := range x {
+			b.WriteString(fmt.Sprintf("- `%s` — %s:%d\n", u.Text, u.File, u.Line))
+		}
+		if n := len(bdd.UndefinedSteps) - len(x); n > 0 {
+			b.WriteString(fmt.Sprintf("- … (%d more)\n", n))
+		}
+		b.WriteString("\n")
+	}
+	if len(bdd.UnusedStepDefs) > 0 {
+		b.WriteString("**Unused step definitions**\n\n")
+		for _, d := range bdd.UnusedStepDefs {
+			b.WriteString(fmt.Sprintf("- `%s` → %s — %s:%d\n", d.Pattern, d.Func, d.File, d.Line))
+		}
+		b.WriteString("\n")
+	}
+}
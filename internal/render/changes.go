@@ -0,0 +1,74 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeChanges renderiza a seção "Changes" do modo -base: arquivos alterados e
+// o que mudou em requires do go.mod, RPCs, migrations e alvos de Make.
+func writeChanges(b *bytes.Buffer, ch *collect.Changes) {
+	if ch == nil {
+		return
+	}
+	added, modified, deleted := 0, 0, 0
+	for _, f := range ch.Files {
+		switch f.Status {
+		case "A":
+			added++
+		case "D":
+			deleted++
+		default:
+			modified++
+		}
+	}
+	head := ch.Head // "working tree" ou hash do commit de -rev
+	if len(head) == 40 {
+		head = "`" + head[:12] + "`"
+	}
+	b.WriteString("## Changes\n\n")
+	b.WriteString(fmt.Sprintf("Base `%s` (merge-base `%.12s`) → %s: **%d** files changed (%d added, %d modified, %d deleted). Collectors below ran on %d files (changed files, their directory neighbors and go.mod files).\n\n",
+		ch.Base, ch.MergeBase, head, len(ch.Files), added, modified, deleted, ch.Analyzed))
+	if len(ch.Files) > 0 {
+		files, more := limitList(ch.Files, 60)
+		for _, f := range files {
+			b.WriteString(fmt.Sprintf("- %s %s\n", f.Status, f.Path))
+		}
+		if more > 0 {
+			b.WriteString("- " + moreNote(more) + "\n")
+		}
+		b.WriteString("\n")
+	}
+	writeListChanges(b, "go.mod requirements", ch.GoRequires)
+	writeListChanges(b, "Proto RPCs", ch.ProtoRPCs)
+	if ch.Migrations != nil {
+		writeListChanges(b, "SQL migrations", []collect.ListChange{*ch.Migrations})
+	}
+	writeListChanges(b, "Make targets", ch.MakeTargets)
+}
+
+func writeListChanges(b *bytes.Buffer, title string, lcs []collect.ListChange) {
+	if len(lcs) == 0 {
+		return
+	}
+	b.WriteString("**" + title + "**\n\n")
+	for _, lc := range lcs {
+		indent := ""
+		if lc.File != "" {
+			b.WriteString("- `" + lc.File + "`\n")
+			indent = "  "
+		}
+		for _, s := range lc.Added {
+			b.WriteString(indent + "- added: " + s + "\n")
+		}
+		for _, s := range lc.Removed {
+			b.WriteString(indent + "- removed: " + s + "\n")
+		}
+		for _, s := range lc.Changed {
+			b.WriteString(indent + "- changed: " + s + "\n")
+		}
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,72 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeCodeGen renderiza a seção "Code Generation": o que é gerado (e não deve
+// ser editado à mão), como regenerar, assets embutidos e build tags.
+func writeCodeGen(b *bytes.Buffer, cg *collect.CodeGen) {
+	if cg == nil {
+		return
+	}
+	b.WriteString("## Code Generation\n\n")
+	if len(cg.Generate) > 0 {
+		b.WriteString("**go:generate**\n\n| Where | Tool | Command |\n|---|---|---|\n")
+		gens, more := limitList(cg.Generate, 60)
+		for _, g := range gens {
+			b.WriteString(fmt.Sprintf("| %s | %s | `%s` |\n", g.Where, g.Tool, strings.ReplaceAll(g.Command, "|", "\\|")))
+		}
+		if more > 0 {
+			b.WriteString("| " + moreNote(more) + " | | |\n")
+		}
+		b.WriteString("\n")
+	}
+	if len(cg.Generated) > 0 {
+		b.WriteString(fmt.Sprintf("**Generated files — do not hand-edit** (%d)\n\n", len(cg.Generated)))
+		b.WriteString("| File | Generator | Regenerate with |\n|---|---|---|\n")
+		generated, more := limitList(cg.Generated, 100)
+		for _, g := range generated {
+			regen := g.Regenerate
+			if regen != "" {
+				regen = "`" + regen + "`"
+			}
+			if g.Directive != "" {
+				regen += " (" + g.Directive + ")"
+			}
+			b.WriteString(fmt.Sprintf("| %s | %s | %s |\n", g.File, g.Generator, regen))
+		}
+		if more > 0 {
+			b.WriteString("| " + moreNote(more) + " | | |\n")
+		}
+		b.WriteString("\n")
+	}
+	if len(cg.Embeds) > 0 {
+		b.WriteString("**go:embed**\n\n")
+		for _, e := range cg.Embeds {
+			line := fmt.Sprintf("- %s", e.Where)
+			if e.Var != "" {
+				line += " `" + e.Var + "`"
+			}
+			line += " ← " + strings.Join(e.Patterns, " ")
+			if len(e.Assets) > 0 {
+				line += fmt.Sprintf(" (%d files: %s)", len(e.Assets), joinList(e.Assets, 5, ", "))
+			} else {
+				line += " (no matching files)"
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+	if len(cg.BuildTags) > 0 {
+		b.WriteString("**Build constraints**\n\n")
+		for _, t := range cg.BuildTags {
+			b.WriteString(fmt.Sprintf("- `%s` — %d files: %s\n", t.Expr, len(t.Files), joinList(t.Files, 5, ", ")))
+		}
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,49 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeNotableConfigs renderiza a seção "Notable Configs" (lint, release, deps...).
+func writeNotableConfigs(b *bytes.Buffer, cfgs []collect.NotableConfig) {
+	if len(cfgs) == 0 {
+		return
+	}
+	b.WriteString("## Notable Configs\n\n")
+	for _, c := range cfgs {
+		b.WriteString(fmt.Sprintf("- `%s` — %s\n", c.File, c.Kind))
+		var keys []string
+		for k := range c.Settings {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		if len(keys) > 15 {
+			keys = keys[:15]
+		}
+		for _, k := range keys {
+			v := c.Settings[k]
+			v = truncate(v, 200)
+			b.WriteString(fmt.Sprintf("  - %s: %s\n", k, v))
+		}
+		if n := len(c.Settings) - len(keys); n > 0 {
+			b.WriteString(fmt.Sprintf("  - … (%d more)\n", n))
+		}
+	}
+	b.WriteString("\n")
+}
+
+// truncate corta s em até n bytes sem partir um caractere UTF-8, marcando o corte com "…".
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "…"
+}
@@ -0,0 +1,26 @@
+package render
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"curto", 10, "curto"},
+		{"abcdef", 3, "abc…"},
+		{"ação", 2, "a…"},
+		{"ação", 3, "aç…"},
+		{"日本語", 5, "日…"},
+	}
+	for _, tt := range tests {
+		got := truncate(tt.in, tt.n)
+		if got != tt.want || !utf8.ValidString(got) {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
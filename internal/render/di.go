@@ -0,0 +1,63 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeDI renderiza a seção "Dependency Injection": módulos fx / sets wire e o
+// grafo de construtores de cada binário.
+func writeDI(b *bytes.Buffer, graphs []collect.DIGraph) {
+	if len(graphs) == 0 {
+		return
+	}
+	b.WriteString("## Dependency Injection\n\n")
+	for _, g := range graphs {
+		switch g.Binary {
+		case "":
+			b.WriteString("**(not reached from a main package)**\n\n")
+		case ".":
+			b.WriteString("**(root main package)**\n\n")
+		default:
+			b.WriteString(fmt.Sprintf("**%s** (`%s`)\n\n", lastPathElem(g.Binary), g.Binary))
+		}
+		for _, m := range g.Modules {
+			line := fmt.Sprintf("- %s `%s` (%s)", m.Kind, m.Name, m.Where)
+			if m.Injects != "" {
+				line += " → `" + m.Injects + "`"
+			}
+			b.WriteString(line + "\n")
+			if len(m.Includes) > 0 {
+				b.WriteString("  - includes: " + strings.Join(m.Includes, ", ") + "\n")
+			}
+			if len(m.Provides) > 0 {
+				b.WriteString("  - provides: " + joinList(m.Provides, 20, ", ") + "\n")
+			}
+			if len(m.Invokes) > 0 {
+				b.WriteString("  - invokes: " + joinList(m.Invokes, 20, ", ") + "\n")
+			}
+		}
+		b.WriteString("\n")
+		if len(g.Providers) > 0 {
+			b.WriteString("| Constructor | Kind | Provides | Needs | Where |\n|---|---|---|---|---|\n")
+			providers := g.Providers
+			if len(providers) > 80 {
+				providers = providers[:80]
+			}
+			for _, p := range providers {
+				b.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s | %s |\n", p.Func, p.Kind,
+					strings.Join(p.Provides, ", "), strings.Join(p.Needs, "<br>"), p.Where))
+			}
+			if n := len(g.Providers) - len(providers); n > 0 {
+				b.WriteString(fmt.Sprintf("| … (%d more) | | | | |\n", n))
+			}
+			b.WriteString("\n")
+		}
+		if len(g.Unresolved) > 0 {
+			b.WriteString("- needed but not provided here: " + joinList(g.Unresolved, 20, ", ") + "\n\n")
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeEventFlows renderiza a seção "Event Flows" (specs AsyncAPI + quem
+// publica/consome cada subject).
+func writeEventFlows(b *bytes.Buffer, specs []collect.AsyncAPISpec, flows []collect.EventFlow) {
+	if len(specs) == 0 && len(flows) == 0 {
+		return
+	}
+	b.WriteString("## Event Flows\n\n")
+	for _, s := range specs {
+		if s.Unparsed != "" {
+			b.WriteString(fmt.Sprintf("- `%s` — _%s spec truncated/unparsed: %s_\n", s.File, s.Format, s.Unparsed))
+			continue
+		}
+		title := s.Title
+		if title == "" {
+			title = "(untitled)"
+		}
+		if s.Version != "" {
+			title += " " + s.Version
+		}
+		b.WriteString(fmt.Sprintf("- `%s` — **%s** (%s, %d channels)\n", s.File, title, s.Format, len(s.Channels)))
+		if len(s.Servers) > 0 {
+			b.WriteString("  - servers: " + joinList(s.Servers, 5, ", ") + "\n")
+		}
+		chs, more := limitList(channelLines(s.Channels), 30)
+		for _, ch := range chs {
+			b.WriteString("  - " + ch + "\n")
+		}
+		if more > 0 {
+			b.WriteString("  - " + moreNote(more) + "\n")
+		}
+	}
+	if len(specs) > 0 {
+		b.WriteString("\n")
+	}
+
+	if len(flows) == 0 {
+		return
+	}
+	b.WriteString("| Subject | Transport | Publishers | Subscribers |\n|---|---|---|---|\n")
+	limit := flows
+	if len(limit) > 80 {
+		limit = limit[:80]
+	}
+	for _, f := range limit {
+		b.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n", f.Subject, strings.Join(f.Transports, ", "),
+			joinList(f.Publishers, 5, "<br>"), joinList(f.Subscribers, 5, "<br>")))
+	}
+	if n := len(flows) - len(limit); n > 0 {
+		b.WriteString(fmt.Sprintf("| … (%d more) | | | |\n", n))
+	}
+	b.WriteString("\n")
+}
+
+// channelLines formata "address [send/receive] — Msg (Payload)".
+func channelLines(chs []collect.AsyncChannel) []string {
+	var out []string
+	for _, ch := range chs {
+		var dir []string
+		if ch.Send {
+			dir = append(dir, "send")
+		}
+		if ch.Receive {
+			dir = append(dir, "receive")
+		}
+		line := "`" + ch.Address + "`"
+		if len(dir) > 0 {
+			line += " [" + strings.Join(dir, "/") + "]"
+		}
+		if len(ch.Messages) > 0 {
+			line += " — " + strings.Join(ch.Messages, ", ")
+		}
+		if len(ch.Payloads) > 0 {
+			line += " (payload: " + strings.Join(ch.Payloads, ", ") + ")"
+		}
+		out = append(out, line)
+	}
+	return out
+}
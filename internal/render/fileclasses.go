@@ -0,0 +1,67 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+var fileClassOrder = []string{"generated", "vendored", "minified", "lockfile"}
+
+// writeFileClasses lista os arquivos que ficaram fora do File Type Stats
+// (gerados, vendorizados, minificados e lockfiles), com as extensões principais.
+func writeFileClasses(b *bytes.Buffer, classes map[string]map[string]int, skipGenerated bool) {
+	if len(classes) == 0 {
+		return
+	}
+	b.WriteString("**Not hand-written** (excluded from the counts above)\n\n| Class | Files | Top extensions |\n|---|---:|---|\n")
+	for _, class := range fileClassOrder {
+		exts := classes[class]
+		if len(exts) == 0 {
+			continue
+		}
+		type kv struct {
+			K string
+			V int
+		}
+		var arr []kv
+		total := 0
+		for k, v := range exts {
+			arr = append(arr, kv{k, v})
+			total += v
+		}
+		sort.Slice(arr, func(i, j int) bool {
+			if arr[i].V != arr[j].V {
+				return arr[i].V > arr[j].V
+			}
+			return arr[i].K < arr[j].K
+		})
+		var top []string
+		for _, it := range arr {
+			if it.K == "" {
+				it.K = "(none)"
+			}
+			top = append(top, fmt.Sprintf("%s (%d)", it.K, it.V))
+		}
+		name := class
+		if class == "generated" && skipGenerated {
+			name += " — skipped by `-skip-generated`"
+		}
+		b.WriteString(fmt.Sprintf("| %s | %d | %s |\n", name, total, joinList(top, 5, ", ")))
+	}
+	b.WriteString("\n")
+}
+
+// formatBytes escreve um tamanho em B/KB/MB/GB (base 1024).
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,108 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeGraphQL renderiza a seção "GraphQL APIs" (SDL + mapeamento gqlgen).
+func writeGraphQL(b *bytes.Buffer, schemas []collect.GraphQLSchema, gqlgen []collect.GqlgenConfig) {
+	if len(schemas) == 0 && len(gqlgen) == 0 {
+		return
+	}
+	b.WriteString("## GraphQL APIs\n\n")
+	for _, s := range schemas {
+		b.WriteString(fmt.Sprintf("- `%s` — %d types, %d queries, %d mutations, %d subscriptions\n",
+			s.File, len(s.Types), len(s.Queries), len(s.Mutations), len(s.Subscriptions)))
+		byKind := map[string][]string{}
+		for _, t := range s.Types {
+			byKind[t.Kind] = append(byKind[t.Kind], t.Name)
+		}
+		var kinds []string
+		for k := range byKind {
+			kinds = append(kinds, k)
+		}
+		sort.Strings(kinds)
+		for _, k := range kinds {
+			b.WriteString(fmt.Sprintf("  - %s: %s\n", k, joinList(byKind[k], 15, ", ")))
+		}
+		if len(s.Directives) > 0 {
+			b.WriteString("  - directives: " + strings.Join(s.Directives, ", ") + "\n")
+		}
+		if len(s.DirectiveDefs) > 0 {
+			b.WriteString("  - declares: " + strings.Join(s.DirectiveDefs, ", ") + "\n")
+		}
+	}
+	b.WriteString("\n")
+
+	var ops [][3]string // kind, name(args), returns
+	for _, s := range schemas {
+		for _, group := range []struct {
+			kind   string
+			fields []collect.GraphQLField
+		}{{"query", s.Queries}, {"mutation", s.Mutations}, {"subscription", s.Subscriptions}} {
+			for _, f := range group.fields {
+				name := f.Name
+				if f.Args != "" {
+					name += "(" + f.Args + ")"
+				}
+				ops = append(ops, [3]string{group.kind, name, f.Returns})
+			}
+		}
+	}
+	if len(ops) > 0 {
+		b.WriteString("| Kind | Field | Returns |\n|---|---|---|\n")
+		limit := ops
+		if len(limit) > 80 {
+			limit = limit[:80]
+		}
+		for _, o := range limit {
+			b.WriteString(fmt.Sprintf("| %s | `%s` | `%s` |\n", o[0], o[1], o[2]))
+		}
+		if n := len(ops) - len(limit); n > 0 {
+			b.WriteString(fmt.Sprintf("| … (%d more) | | |\n", n))
+		}
+		b.WriteString("\n")
+	}
+
+	for _, g := range gqlgen {
+		b.WriteString(fmt.Sprintf("- **gqlgen** `%s` — schema: %s\n", g.File, strings.Join(g.SchemaGlobs, ", ")))
+		var pkgs []string
+		if g.ExecPackage != "" {
+			pkgs = append(pkgs, "exec: "+g.ExecPackage)
+		}
+		if g.ModelPackage != "" {
+			pkgs = append(pkgs, "model: "+g.ModelPackage)
+		}
+		if g.ResolverDir != "" {
+			r := "resolver: " + g.ResolverDir
+			if g.ResolverPackage != "" {
+				r += " (package " + g.ResolverPackage + ")"
+			}
+			if g.ResolverLayout != "" {
+				r += " [" + g.ResolverLayout + "]"
+			}
+			pkgs = append(pkgs, r)
+		}
+		if len(pkgs) > 0 {
+			b.WriteString("  - " + strings.Join(pkgs, " · ") + "\n")
+		}
+		for _, s := range g.Schemas {
+			if res, ok := g.Resolvers[s]; ok {
+				b.WriteString(fmt.Sprintf("  - %s → %s\n", s, res))
+			} else {
+				b.WriteString("  - " + s + "\n")
+			}
+		}
+		if len(g.Autobind) > 0 {
+			b.WriteString("  - autobind: " + strings.Join(g.Autobind, ", ") + "\n")
+		}
+	}
+	if len(gqlgen) > 0 {
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,62 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeHistory renderiza a seção "Recent Activity": hotspots de churn, últimos
+// commits e contribuidores por área, e o que está parado há mais de um ano.
+func writeHistory(b *bytes.Buffer, h *collect.History) {
+	if h == nil {
+		return
+	}
+	b.WriteString("## Recent Activity\n\n")
+	b.WriteString(fmt.Sprintf("Last %d days: **%d** commits by **%d** authors (merges excluded).", h.Days, h.Commits, h.Authors))
+	if h.ShallowClone {
+		b.WriteString(" _Shallow clone: history may be truncated._")
+	}
+	b.WriteString("\n\n")
+	if len(h.HotFiles) > 0 {
+		b.WriteString("**Hotspots (most changed files)**\n\n| File | Commits | Authors |\n|---|---:|---:|\n")
+		for _, c := range h.HotFiles {
+			b.WriteString(fmt.Sprintf("| %s | %d | %d |\n", c.Path, c.Commits, c.Authors))
+		}
+		b.WriteString("\n")
+	}
+	if len(h.HotDirs) > 0 {
+		var dirs []string
+		for _, c := range h.HotDirs {
+			dirs = append(dirs, fmt.Sprintf("%s (%d)", c.Path, c.Commits))
+		}
+		b.WriteString("**Most changed directories:** " + strings.Join(dirs, ", ") + "\n\n")
+	}
+	if len(h.Areas) > 0 {
+		b.WriteString("**By area**\n\n")
+		areas, more := limitList(h.Areas, 25)
+		for _, a := range areas {
+			var who []string
+			for _, c := range a.Contributors {
+				who = append(who, fmt.Sprintf("%s (%d)", c.Name, c.Commits))
+			}
+			b.WriteString(fmt.Sprintf("- `%s` — %d commits, last %s; contributors: %s\n", a.Area, a.Commits, a.LastCommit.Format("2006-01-02"), strings.Join(who, ", ")))
+			for _, c := range a.Latest {
+				b.WriteString(fmt.Sprintf("  - %s %s %s (%s)\n", c.Hash, c.Date.Format("2006-01-02"), c.Subject, c.Author))
+			}
+		}
+		if more > 0 {
+			b.WriteString("- " + moreNote(more) + "\n")
+		}
+		b.WriteString("\n")
+	}
+	if h.StaleFiles > 0 {
+		var areas []string
+		for _, a := range h.StaleByArea {
+			areas = append(areas, fmt.Sprintf("%s (%d)", a.Area, a.Files))
+		}
+		b.WriteString(fmt.Sprintf("**Untouched for over a year:** %d files — %s\n\n", h.StaleFiles, joinList(areas, 15, ", ")))
+	}
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeJSPackages renderiza a seção "JS/TS Packages" (package.json + workspaces).
+func writeJSPackages(b *bytes.Buffer, pkgs []collect.JSPackage) {
+	if len(pkgs) == 0 {
+		return
+	}
+	b.WriteString("## JS/TS Packages\n\n")
+	for _, p := range pkgs {
+		title := p.Name
+		if title == "" {
+			title = "(unnamed)"
+		}
+		if p.Version != "" {
+			title += "@" + p.Version
+		}
+		var meta []string
+		if p.Private {
+			meta = append(meta, "private")
+		}
+		if p.Manager != "" {
+			meta = append(meta, p.Manager)
+		}
+		if len(p.Frameworks) > 0 {
+			meta = append(meta, strings.Join(p.Frameworks, ", "))
+		}
+		line := fmt.Sprintf("- `%s` — **%s**", p.Dir, title)
+		if len(meta) > 0 {
+			line += " (" + strings.Join(meta, " · ") + ")"
+		}
+		b.WriteString(line + "\n")
+
+		if len(p.Workspaces) > 0 {
+			b.WriteString(fmt.Sprintf("  - workspaces: %s → %d members\n", strings.Join(p.Workspaces, ", "), len(p.Members)))
+		}
+		if len(p.Engines) > 0 {
+			b.WriteString("  - engines: " + strings.Join(mapPairs(p.Engines, " "), ", ") + "\n")
+		}
+		if len(p.Internal) > 0 {
+			b.WriteString("  - internal deps: " + strings.Join(p.Internal, ", ") + "\n")
+		}
+		if len(p.Dependents) > 0 {
+			b.WriteString("  - used by: " + joinList(p.Dependents, 12, ", ") + "\n")
+		}
+		if len(p.Dependencies) > 0 {
+			b.WriteString("  - deps: " + joinList(mapKeys(p.Dependencies), 15, ", ") + "\n")
+		}
+		if len(p.DevDependencies) > 0 {
+			b.WriteString("  - dev deps: " + joinList(mapKeys(p.DevDependencies), 12, ", ") + "\n")
+		}
+		if len(p.PeerDeps) > 0 {
+			b.WriteString("  - peer deps: " + joinList(mapKeys(p.PeerDeps), 8, ", ") + "\n")
+		}
+		if len(p.Bin) > 0 {
+			b.WriteString("  - bin: " + strings.Join(mapPairs(p.Bin, " → "), ", ") + "\n")
+		}
+		if len(p.Exports) > 0 {
+			b.WriteString("  - exports: " + joinList(p.Exports, 10, ", ") + "\n")
+		}
+		if len(p.TSPaths) > 0 {
+			b.WriteString("  - ts paths: " + joinList(mapPairs(p.TSPaths, " → "), 10, ", ") + "\n")
+		}
+	}
+	b.WriteString("\n")
+}
+
+func mapKeys(m map[string]string) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// mapPairs formata "chave<sep>valor" em ordem de chave.
+func mapPairs(m map[string]string, sep string) []string {
+	var out []string
+	for _, k := range mapKeys(m) {
+		out = append(out, k+sep+m[k])
+	}
+	return out
+}
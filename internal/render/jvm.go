@@ -0,0 +1,47 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeJVMProjects renderiza a seção "JVM Builds" (Maven/Gradle).
+func writeJVMProjects(b *bytes.Buffer, projects []collect.JVMProject) {
+	if len(projects) == 0 {
+		return
+	}
+	b.WriteString("## JVM Builds\n\n")
+	for _, p := range projects {
+		title := p.Name
+		if p.Version != "" {
+			title += ":" + p.Version
+		}
+		meta := []string{p.Tool}
+		if p.Language != "" {
+			meta = append(meta, p.Language)
+		}
+		if p.Packaging != "" {
+			meta = append(meta, p.Packaging)
+		}
+		b.WriteString(fmt.Sprintf("- `%s` — **%s** (%s)\n", p.Dir, title, strings.Join(meta, " · ")))
+		if p.Parent != "" {
+			b.WriteString("  - parent: `" + p.Parent + "`\n")
+		}
+		if len(p.Modules) > 0 {
+			b.WriteString("  - modules: " + joinList(p.Modules, 20, ", ") + "\n")
+		}
+		if len(p.Plugins) > 0 {
+			b.WriteString("  - plugins: " + joinList(p.Plugins, 12, ", ") + "\n")
+		}
+		if len(p.Internal) > 0 {
+			b.WriteString("  - internal: " + strings.Join(p.Internal, ", ") + "\n")
+		}
+		if len(p.Dependencies) > 0 {
+			b.WriteString("  - deps: " + joinList(p.Dependencies, 15, ", ") + "\n")
+		}
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,33 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeLanguageStats renderiza a seção "Languages": linhas de código,
+// comentário e em branco por linguagem e os maiores arquivos.
+func writeLanguageStats(b *bytes.Buffer, st *collect.LanguageStats) {
+	if st == nil {
+		return
+	}
+	b.WriteString("## Languages\n\n| Language | Files | Code | Comment | Blank |\n|---|---:|---:|---:|---:|\n")
+	langs, more := limitList(st.Languages, 30)
+	for _, l := range langs {
+		b.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d |\n", l.Language, l.Files, l.Code, l.Comment, l.Blank))
+	}
+	if more > 0 {
+		b.WriteString("| " + moreNote(more) + " | | | | |\n")
+	}
+	t := st.Total
+	b.WriteString(fmt.Sprintf("| **Total** | **%d** | **%d** | **%d** | **%d** |\n\n", t.Files, t.Code, t.Comment, t.Blank))
+	if len(st.Largest) > 0 {
+		b.WriteString("**Largest files**\n\n")
+		for _, f := range st.Largest {
+			b.WriteString(fmt.Sprintf("- %s — %d lines (%d code, %s)\n", f.File, f.Lines, f.Code, f.Language))
+		}
+		b.WriteString("\n")
+	}
+}
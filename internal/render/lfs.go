@@ -0,0 +1,59 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeLargeAssets renderiza a seção "Large Assets": o que está no Git LFS
+// (com o tamanho real, agrupado por diretório) e os arquivos grandes fora dele.
+func writeLargeAssets(b *bytes.Buffer, lfs *collect.LFSSummary, large []collect.LargeAsset, threshold int64) {
+	if lfs == nil && len(large) == 0 {
+		return
+	}
+	b.WriteString("## Large Assets\n\n")
+	if lfs != nil {
+		b.WriteString(fmt.Sprintf("**Git LFS** — %d files, %s\n\n", lfs.Files, formatBytes(lfs.Bytes)))
+		if len(lfs.Patterns) > 0 {
+			var pats []string
+			for _, p := range lfs.Patterns {
+				if dir := path.Dir(p.File); dir != "." {
+					pats = append(pats, fmt.Sprintf("`%s` (%s)", p.Pattern, dir))
+				} else {
+					pats = append(pats, "`"+p.Pattern+"`")
+				}
+			}
+			b.WriteString("- tracked patterns: " + joinList(pats, 20, ", ") + "\n\n")
+		}
+		if len(lfs.Dirs) > 0 {
+			b.WriteString("| Dir | Files | Size | Largest |\n|---|---:|---:|---|\n")
+			dirs, more := limitList(lfs.Dirs, 30)
+			for _, d := range dirs {
+				top := d.Assets[0]
+				note := ""
+				if !top.Pointer {
+					note = ", downloaded"
+				}
+				b.WriteString(fmt.Sprintf("| %s | %d | %s | %s (%s%s) |\n", d.Dir, d.Files, formatBytes(d.Bytes), path.Base(top.File), formatBytes(top.Size), note))
+			}
+			if more > 0 {
+				b.WriteString("| " + moreNote(more) + " | | | |\n")
+			}
+			b.WriteString("\n")
+		}
+	}
+	if len(large) > 0 {
+		b.WriteString(fmt.Sprintf("**Not in LFS, ≥ %s** (%d)\n\n", formatBytes(threshold), len(large)))
+		shown, more := limitList(large, 30)
+		for _, a := range shown {
+			b.WriteString(fmt.Sprintf("- %s — %s\n", a.File, formatBytes(a.Size)))
+		}
+		if more > 0 {
+			b.WriteString("- " + moreNote(more) + "\n")
+		}
+		b.WriteString("\n")
+	}
+}
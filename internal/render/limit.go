@@ -0,0 +1,29 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+)
+
+// limitList corta a lista em n itens e devolve quantos ficaram de fora; quem
+// renderiza marca o excedente com moreNote.
+func limitList[T any](in []T, n int) ([]T, int) {
+	if len(in) <= n {
+		return in, 0
+	}
+	return in[:n:n], len(in) - n
+}
+
+// moreNote é o marcador de lista truncada usado em todo o relatório.
+func moreNote(n int) string {
+	return fmt.Sprintf("… (%d more)", n)
+}
+
+// joinList junta até n itens com sep, terminando com moreNote se cortou.
+func joinList(in []string, n int, sep string) string {
+	kept, more := limitList(in, n)
+	if more > 0 {
+		kept = append(kept, moreNote(more))
+	}
+	return strings.Join(kept, sep)
+}
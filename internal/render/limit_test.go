@@ -0,0 +1,29 @@
+package render
+
+import "testing"
+
+func TestJoinList(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []string
+		n    int
+		want string
+	}{
+		{"cabe", []string{"a", "b"}, 2, "a, b"},
+		{"corta", []string{"a", "b", "c", "d"}, 2, "a, b, … (2 more)"},
+		{"vazia", nil, 3, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := joinList(tt.in, tt.n, ", "); got != tt.want {
+				t.Errorf("joinList(%v, %d) = %q, want %q", tt.in, tt.n, got, tt.want)
+			}
+		})
+	}
+	in := []string{"a", "b", "c"}
+	kept, more := limitList(in, 2)
+	_ = append(kept, "x")
+	if in[2] != "c" || more != 1 {
+		t.Errorf("limitList alterou a entrada ou contou errado: %v, %d", in, more)
+	}
+}
@@ -0,0 +1,65 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// treeOwnerNotes devolve, para cada linha da árvore, um sufixo "  # @owner"
+// nos diretórios cujos owners diferem dos do diretório pai. O caminho de cada
+// linha é reconstruído pela indentação (dois espaços por nível).
+func treeOwnerNotes(tree []string, own *collect.Ownership) []string {
+	notes := make([]string, len(tree))
+	if own == nil {
+		return notes
+	}
+	var stack []string
+	for i, line := range tree {
+		name := strings.TrimLeft(line, " ")
+		level := (len(line) - len(name)) / 2
+		if level == 0 {
+			continue // raiz
+		}
+		if level-1 < len(stack) {
+			stack = stack[:level-1]
+		}
+		stack = append(stack, name)
+		dir := strings.Join(stack, "/")
+		owners, ok := own.Dirs[dir]
+		if !ok {
+			continue
+		}
+		parent := strings.Join(stack[:len(stack)-1], "/")
+		if strings.Join(own.Dirs[parent], " ") == strings.Join(owners, " ") {
+			continue
+		}
+		notes[i] = "  # " + strings.Join(owners, " ")
+	}
+	return notes
+}
+
+// writeOwnership renderiza a seção "Ownership": o que cada owner do CODEOWNERS
+// possui (diretórios, módulos Go, pacotes proto) e os diretórios sem dono.
+func writeOwnership(b *bytes.Buffer, own *collect.Ownership) {
+	if own == nil {
+		return
+	}
+	b.WriteString("## Ownership\n\n")
+	b.WriteString(fmt.Sprintf("Source: `%s` (%d rules, last match wins)\n\n", own.File, len(own.Rules)))
+	if len(own.Teams) > 0 {
+		b.WriteString("| Owner | Directories | Go modules | Proto packages |\n|---|---|---|---|\n")
+		for _, t := range own.Teams {
+			b.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", t.Owner,
+				joinList(t.Dirs, 12, ", "),
+				joinList(t.Modules, 8, ", "),
+				joinList(t.Protos, 8, ", ")))
+		}
+		b.WriteString("\n")
+	}
+	if len(own.Unowned) > 0 {
+		b.WriteString("**Unowned top-level directories:** " + strings.Join(own.Unowned, ", ") + "\n\n")
+	}
+}
@@ -0,0 +1,83 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writePythonProjects renderiza a seção "Python Projects" (um item por diretório).
+func writePythonProjects(b *bytes.Buffer, projects []collect.PythonProject) {
+	if len(projects) == 0 {
+		return
+	}
+	b.WriteString("## Python Projects\n\n")
+	for _, p := range projects {
+		title := p.Name
+		if title == "" {
+			title = "(unnamed)"
+		}
+		switch p.Version {
+		case "":
+		case "dynamic":
+			title += " (dynamic version)"
+		default:
+			title += " " + p.Version
+		}
+		b.WriteString(fmt.Sprintf("- `%s` — **%s**\n", p.Dir, title))
+		var meta []string
+		if len(p.Tools) > 0 {
+			meta = append(meta, "tools: "+strings.Join(p.Tools, ", "))
+		}
+		if p.BuildBackend != "" {
+			meta = append(meta, "backend: `"+p.BuildBackend+"`")
+		}
+		if p.RequiresPython != "" {
+			meta = append(meta, "python: `"+p.RequiresPython+"`")
+		}
+		if p.Layout != "" {
+			meta = append(meta, "layout: "+p.Layout)
+		}
+		if len(meta) > 0 {
+			b.WriteString("  - " + strings.Join(meta, " · ") + "\n")
+		}
+		b.WriteString("  - manifests: " + strings.Join(p.Sources, ", ") + "\n")
+		if len(p.Packages) > 0 {
+			b.WriteString("  - packages: " + joinList(p.Packages, 12, ", ") + "\n")
+		}
+		if len(p.Dependencies) > 0 {
+			b.WriteString("  - deps: " + joinList(p.Dependencies, 15, ", ") + "\n")
+		}
+		if len(p.DevDependencies) > 0 {
+			b.WriteString("  - dev deps: " + joinList(p.DevDependencies, 12, ", ") + "\n")
+		}
+		if len(p.Extras) > 0 {
+			var names []string
+			for k := range p.Extras {
+				names = append(names, k)
+			}
+			sort.Strings(names)
+			var parts []string
+			for _, k := range names {
+				parts = append(parts, fmt.Sprintf("%s (%s)", k, joinList(p.Extras[k], 6, ", ")))
+			}
+			b.WriteString("  - extras: " + strings.Join(parts, "; ") + "\n")
+		}
+		if len(p.Scripts) > 0 {
+			var names []string
+			for k := range p.Scripts {
+				names = append(names, k)
+			}
+			sort.Strings(names)
+			var parts []string
+			for _, k := range names {
+				parts = append(parts, fmt.Sprintf("`%s` → `%s`", k, p.Scripts[k]))
+			}
+			b.WriteString("  - scripts: " + joinList(parts, 10, ", ") + "\n")
+		}
+	}
+	b.WriteString("\n")
+}
@@ -24,6 +24,10 @@ func BuildArtifacts(sum *collect.Summary) (markdown string, jsonBytes []byte, er
 	fmt.Fprintf(&b, "---\n")
 	fmt.Fprintf(&b, "generated_at: %s\n", sum.GeneratedAt.Format(time.RFC3339))
 	fmt.Fprintf(&b, "root: %s\n", sum.Root)
+	if sum.Commit != "" {
+		fmt.Fprintf(&b, "rev: %s\n", sum.Rev)
+		fmt.Fprintf(&b, "commit: %s\n", sum.Commit)
+	}
 	fmt.Fprintf(&b, "go_modules: %d\n", len(sum.GoModules))
 	fmt.Fprintf(&b, "proto_files: %d\n", len(sum.Proto))
 	fmt.Fprintf(&b, "sql_migrations: %d\n", len(sum.SQLMigrations))
@@ -45,13 +49,23 @@ func BuildArtifacts(sum *collect.Summary) (markdown string, jsonBytes []byte, er
 	b.WriteString(fmt.Sprintf("| README files | %d |\n", len(sum.Readmes)))
 	b.WriteString("\n")
 
+	// Modo -base: o que o branch mudou
+	writeChanges(&b, sum.Changes)
+
 	// Tree (pruned)
 	b.WriteString("## Repository Tree (pruned)\n\n```\n")
-	for _, line := range sum.Tree {
-		b.WriteString(line + "\n")
+	treeOwners := treeOwnerNotes(sum.Tree, sum.Ownership)
+	for i, line := range sum.Tree {
+		b.WriteString(line + treeOwners[i] + "\n")
 	}
 	b.WriteString("```\n\n")
 
+	// Histórico git recente
+	writeHistory(&b, sum.History)
+
+	// CODEOWNERS por owner
+	writeOwnership(&b, sum.Ownership)
+
 	// Test Coverage (Go + BDD)
 	if sum.TestCoverage != nil {
 		b.WriteString("## Test Coverage\n\n")
@@ -63,6 +77,9 @@ func BuildArtifacts(sum *collect.Summary) (markdown string, jsonBytes []byte, er
 		} else {
 			b.WriteString("- **Go coverage:** (no coverprofile found)\n")
 		}
+		if len(sum.TestCoverage.LeastCovered) > 0 {
+			b.WriteString("  - **least covered packages:** " + strings.Join(sum.TestCoverage.LeastCovered, ", ") + "\n")
+		}
 
 		// BDD coverage / signals
 		if sum.TestCoverage.BDD.FeatureFiles > 0 || len(sum.TestCoverage.BDD.Reports) > 0 {
@@ -71,6 +88,13 @@ func BuildArtifacts(sum *collect.Summary) (markdown string, jsonBytes []byte, er
 			if sum.TestCoverage.BDD.Features+sum.TestCoverage.BDD.Scenarios+sum.TestCoverage.BDD.Steps > 0 {
 				b.WriteString(fmt.Sprintf("    - cucumber totals: features=%d, scenarios=%d, steps=%d\n",
 					sum.TestCoverage.BDD.Features, sum.TestCoverage.BDD.Scenarios, sum.TestCoverage.BDD.Steps))
+				bdd := sum.TestCoverage.BDD
+				b.WriteString(fmt.Sprintf("    - step status: passed=%d, failed=%d, skipped=%d, pending=%d, undefined=%d\n",
+					bdd.Passed, bdd.Failed, bdd.Skipped, bdd.Pending, bdd.Undefined))
+			}
+			if sum.TestCoverage.BDD.StepDefinitions > 0 {
+				b.WriteString(fmt.Sprintf("    - godog step definitions: %d (undefined steps=%d, unused definitions=%d)\n",
+					sum.TestCoverage.BDD.StepDefinitions, len(sum.TestCoverage.BDD.UndefinedSteps), len(sum.TestCoverage.BDD.UnusedStepDefs)))
 			}
 			if len(sum.TestCoverage.BDD.Reports) > 0 {
 				// limitar listagem para não inflar contexto
@@ -82,6 +106,67 @@ func BuildArtifacts(sum *collect.Summary) (markdown string, jsonBytes []byte, er
 			}
 		}
 		b.WriteString("\n")
+
+		// Cobertura por pacote (ordenada da menor para a maior)
+		if len(sum.TestCoverage.Packages) > 0 {
+			pkgs := append([]collect.PackageCoverage(nil), sum.TestCoverage.Packages...)
+			sort.SliceStable(pkgs, func(i, j int) bool { return pkgs[i].Percent < pkgs[j].Percent })
+			b.WriteString("**Coverage by package**\n\n")
+			b.WriteString("| Package | Covered | Stmts | % |\n|---|---:|---:|---:|\n")
+			limit := pkgs
+			if len(limit) > 30 {
+				limit = limit[:30]
+			}
+			for _, p := range limit {
+				b.WriteString(fmt.Sprintf("| %s | %d | %d | %.1f |\n", p.Package, p.CoveredStmts, p.TotalStmts, p.Percent))
+			}
+			if len(pkgs) > len(limit) {
+				b.WriteString(fmt.Sprintf("| … (%d more) | | | |\n", len(pkgs)-len(limit)))
+			}
+			b.WriteString("\n")
+		}
+
+		// Arquivos menos cobertos
+		if len(sum.TestCoverage.Files) > 0 {
+			fs := append([]collect.FileCoverage(nil), sum.TestCoverage.Files...)
+			sort.SliceStable(fs, func(i, j int) bool { return fs[i].Percent < fs[j].Percent })
+			if len(fs) > 15 {
+				fs = fs[:15]
+			}
+			b.WriteString("**Least covered files**\n\n")
+			for _, f := range fs {
+				b.WriteString(fmt.Sprintf("- %s — %.1f%% (%d/%d)\n", f.File, f.Percent, f.CoveredStmts, f.TotalStmts))
+			}
+			b.WriteString("\n")
+		}
+
+		// Funções exportadas sem cobertura
+		if len(sum.TestCoverage.UncoveredFuncs) > 0 {
+			b.WriteString("**Uncovered exported functions**\n\n")
+			x := sum.TestCoverage.UncoveredFuncs
+			if len(x) > 40 {
+				x = x[:40]
+			}
+			for _, u := range x {
+				b.WriteString(fmt.Sprintf("- `%s.%s` — %s:%d (%d stmts)\n", lastPathElem(u.Package), u.Func, u.File, u.Line, u.Stmts))
+			}
+			if n := len(sum.TestCoverage.UncoveredFuncs) - len(x); n > 0 {
+				b.WriteString(fmt.Sprintf("- … (%d more)\n", n))
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	// Test inventory (static, from _test.go)
+	writeTestInventory(&b, sum.Tests)
+
+	// Test results (JUnit / go test -json)
+	writeTestResults(&b, sum.TestResults)
+
+	// Behavior catalog (Gherkin)
+	if sum.TestCoverage != nil {
+		writeBehaviorCatalog(&b, sum.TestCoverage.BDD.Specs)
+		writeBDDResults(&b, &sum.TestCoverage.BDD)
 	}
 
 	// Go modules
@@ -97,15 +182,36 @@ func BuildArtifacts(sum *collect.Summary) (markdown string, jsonBytes []byte, er
 				}
 				b.WriteString("  - deps: " + strings.Join(uniq, ", ") + "\n")
 			}
+			if len(m.Internal) > 0 {
+				b.WriteString("  - internal: " + strings.Join(m.Internal, ", ") + "\n")
+			}
+			if len(m.Owners) > 0 {
+				b.WriteString("  - owners: " + strings.Join(m.Owners, " ") + "\n")
+			}
 		}
 		b.WriteString("\n")
 	}
 
+	// Rust (Cargo workspaces e crates)
+	writeRustCrates(&b, sum.RustCrates, sum.RustWorkspaces)
+
+	// Python (pyproject, setup.cfg, Pipfile, requirements)
+	writePythonProjects(&b, sum.PythonProjects)
+
+	// JS/TS (package.json, workspaces)
+	writeJSPackages(&b, sum.JSPackages)
+
+	// JVM (Maven, Gradle)
+	writeJVMProjects(&b, sum.JVMProjects)
+
 	// Proto summary
 	if len(sum.Proto) > 0 {
 		b.WriteString("## Protobuf APIs\n\n")
 		for _, p := range sum.Proto {
 			b.WriteString(fmt.Sprintf("- `%s` — package: `%s`\n", p.File, p.Package))
+			if len(p.Owners) > 0 {
+				b.WriteString("  - owners: " + strings.Join(p.Owners, " ") + "\n")
+			}
 			if len(p.Services) > 0 {
 				b.WriteString("  - services: " + strings.Join(p.Services, ", ") + "\n")
 			}
@@ -116,10 +222,45 @@ func BuildArtifacts(sum *collect.Summary) (markdown string, jsonBytes []byte, er
 				}
 				b.WriteString("  - rpcs: " + strings.Join(x, ", ") + "\n")
 			}
+			for _, impl := range p.Impls {
+				stub := ""
+				if impl.Embeds {
+					stub = ", embeds Unimplemented" + impl.Service + "Server"
+				}
+				b.WriteString(fmt.Sprintf("  - `%s` → `%s` (%s%s)\n", impl.Service, impl.Type, impl.Where, stub))
+				for _, m := range impl.Methods {
+					if m.Unimplemented {
+						b.WriteString(fmt.Sprintf("    - %s → **unimplemented** (stub)\n", m.RPC))
+					} else {
+						b.WriteString(fmt.Sprintf("    - %s → %s\n", m.RPC, m.Where))
+					}
+				}
+			}
+			if len(p.NoImpl) > 0 {
+				b.WriteString("  - no Go implementation found: " + strings.Join(p.NoImpl, ", ") + "\n")
+			}
 		}
 		b.WriteString("\n")
 	}
 
+	// REST (OpenAPI/Swagger)
+	writeRESTAPIs(&b, sum.APISpecs)
+
+	// Rotas HTTP extraídas do código Go
+	writeHTTPEndpoints(&b, sum.HTTPEndpoints)
+
+	// Injeção de dependências (fx/wire)
+	writeDI(&b, sum.DI)
+
+	// Geração de código (go:generate, arquivos gerados, go:embed, build tags)
+	writeCodeGen(&b, sum.CodeGen)
+
+	// GraphQL (SDL, gqlgen)
+	writeGraphQL(&b, sum.GraphQLSchemas, sum.Gqlgen)
+
+	// Eventos (AsyncAPI, nats/kafka/amqp no código Go)
+	writeEventFlows(&b, sum.AsyncAPIs, sum.EventFlows)
+
 	// Make targets
 	if len(sum.MakeTargets) > 0 {
 		b.WriteString("## Make Targets (top-level)\n\n")
@@ -222,8 +363,17 @@ func BuildArtifacts(sum *collect.Summary) (markdown string, jsonBytes []byte, er
 		}
 	}
 
+	// Notable configs (lint, release, deps, editor...)
+	writeNotableConfigs(&b, sum.NotableConfigs)
+
+	// Git LFS e arquivos grandes
+	writeLargeAssets(&b, sum.LFS, sum.LargeAssets, sum.LargeAssetBytes)
+
+	// Linhas por linguagem
+	writeLanguageStats(&b, sum.LanguageStats)
+
 	// Tech stats
-	if len(sum.TechStats) > 0 {
+	if len(sum.TechStats) > 0 || len(sum.FileClasses) > 0 || sum.Binaries.Files > 0 {
 		b.WriteString("## File Type Stats\n\n")
 		type kv struct {
 			K string
@@ -246,6 +396,10 @@ func BuildArtifacts(sum *collect.Summary) (markdown string, jsonBytes []byte, er
 			b.WriteString(fmt.Sprintf("| %s | %d |\n", it.K, it.V))
 		}
 		b.WriteString("\n")
+		writeFileClasses(&b, sum.FileClasses, sum.SkipGenerated)
+		if sum.Binaries.Files > 0 {
+			b.WriteString(fmt.Sprintf("_Binary files skipped (detected by content): %d, %s._\n\n", sum.Binaries.Files, formatBytes(sum.Binaries.Bytes)))
+		}
 	}
 
 	// Footer
@@ -270,3 +424,10 @@ func uniqueSorted(in []string) []string {
 	sort.Strings(out)
 	return out
 }
+
+func lastPathElem(p string) string {
+	if i := strings.LastIndex(p, "/"); i >= 0 {
+		return p[i+1:]
+	}
+	return p
+}
@@ -0,0 +1,41 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeHTTPEndpoints renderiza a seção "HTTP Endpoints": rotas extraídas do
+// código Go, agrupadas pelo binário (pacote main) que as registra.
+func writeHTTPEndpoints(b *bytes.Buffer, services []collect.HTTPService) {
+	if len(services) == 0 {
+		return
+	}
+	b.WriteString("## HTTP Endpoints\n\n")
+	for _, s := range services {
+		switch s.Binary {
+		case ".":
+			b.WriteString(fmt.Sprintf("**(root main package)** (%d routes)\n\n", len(s.Routes)))
+		case "":
+			b.WriteString(fmt.Sprintf("**(not reached from a main package)** (%d routes)\n\n", len(s.Routes)))
+		default:
+			b.WriteString(fmt.Sprintf("**%s** (`%s`, %d routes)\n\n", lastPathElem(s.Binary), s.Binary, len(s.Routes)))
+		}
+		b.WriteString("| Method | Path | Handler | Framework | Where |\n|---|---|---|---|---|\n")
+		routes := s.Routes
+		if len(routes) > 100 {
+			routes = routes[:100]
+		}
+		for _, r := range routes {
+			b.WriteString(fmt.Sprintf("| %s | `%s` | `%s` | %s | %s |\n",
+				r.Method, r.Path, strings.ReplaceAll(r.Handler, "|", "\\|"), r.Framework, r.Where))
+		}
+		if n := len(s.Routes) - len(routes); n > 0 {
+			b.WriteString(fmt.Sprintf("| … (%d more) | | | | |\n", n))
+		}
+		b.WriteString("\n")
+	}
+}
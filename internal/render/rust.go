@@ -0,0 +1,85 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeRustCrates renderiza a seção "Rust Crates" (workspaces Cargo + crates).
+func writeRustCrates(b *bytes.Buffer, crates []collect.RustCrate, workspaces []collect.RustWorkspace) {
+	if len(crates) == 0 && len(workspaces) == 0 {
+		return
+	}
+	b.WriteString("## Rust Crates\n\n")
+	for _, ws := range workspaces {
+		line := fmt.Sprintf("- **workspace** `%s` — %d crates", ws.Dir, len(ws.Crates))
+		var meta []string
+		if ws.Resolver != "" {
+			meta = append(meta, "resolver "+ws.Resolver)
+		}
+		if ws.Edition != "" {
+			meta = append(meta, "edition "+ws.Edition)
+		}
+		if len(meta) > 0 {
+			line += " (" + strings.Join(meta, ", ") + ")"
+		}
+		b.WriteString(line + "\n")
+		if len(ws.Members) > 0 {
+			b.WriteString("  - members: " + strings.Join(ws.Members, ", ") + "\n")
+		}
+		if len(ws.SharedDeps) > 0 {
+			b.WriteString("  - shared deps: " + joinList(ws.SharedDeps, 15, ", ") + "\n")
+		}
+	}
+	if len(workspaces) > 0 {
+		b.WriteString("\n")
+	}
+
+	for _, c := range crates {
+		title := c.Name
+		if c.Version != "" {
+			title += " " + c.Version
+		}
+		var meta []string
+		if len(c.Kinds) > 0 {
+			meta = append(meta, strings.Join(c.Kinds, "+"))
+		}
+		if c.Edition != "" {
+			meta = append(meta, "edition "+c.Edition)
+		}
+		line := fmt.Sprintf("- `%s` — **%s**", c.Dir, title)
+		if len(meta) > 0 {
+			line += " (" + strings.Join(meta, ", ") + ")"
+		}
+		b.WriteString(line + "\n")
+		if len(c.Bins) > 0 {
+			b.WriteString("  - bins: " + strings.Join(c.Bins, ", ") + "\n")
+		}
+		if len(c.Features) > 0 || len(c.DefaultFeatures) > 0 {
+			s := joinList(c.Features, 12, ", ")
+			if len(c.DefaultFeatures) > 0 {
+				s += " (default: " + strings.Join(c.DefaultFeatures, ", ") + ")"
+			}
+			b.WriteString("  - features: " + strings.TrimSpace(s) + "\n")
+		}
+		if len(c.Internal) > 0 {
+			b.WriteString("  - internal deps: " + strings.Join(c.Internal, ", ") + "\n")
+		}
+		if len(c.Dependents) > 0 {
+			b.WriteString("  - used by: " + joinList(c.Dependents, 12, ", ") + "\n")
+		}
+		if len(c.Dependencies) > 0 {
+			b.WriteString("  - deps: " + joinList(c.Dependencies, 15, ", ") + "\n")
+		}
+		if len(c.DevDependencies) > 0 {
+			b.WriteString("  - dev deps: " + joinList(c.DevDependencies, 10, ", ") + "\n")
+		}
+		if len(c.BuildDependencies) > 0 {
+			b.WriteString("  - build deps: " + joinList(c.BuildDependencies, 8, ", ") + "\n")
+		}
+	}
+	b.WriteString("\n")
+}
@@ -0,0 +1,107 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/richardanchieta/llm-scan-tool/internal/collect"
+)
+
+// writeTestResults renderiza a seção "Test Results" (JUnit/xUnit, go test -json).
+func writeTestResults(b *bytes.Buffer, tr *collect.TestResults) {
+	if tr == nil || tr.Tests == 0 {
+		return
+	}
+	b.WriteString("## Test Results\n\n")
+	b.WriteString(fmt.Sprintf("- **Totals:** %d tests in %d suites — passed=%d, failed=%d, errors=%d, skipped=%d (%.2fs)\n",
+		tr.Tests, tr.Suites, tr.Passed, tr.Failed, tr.Errors, tr.Skipped, tr.Seconds))
+	lim := tr.Reports
+	if len(lim) > 8 {
+		lim = append(lim[:8:8], "…")
+	}
+	b.WriteString("- **Reports:** " + strings.Join(lim, ", ") + "\n\n")
+
+	if len(tr.Failing) > 0 {
+		b.WriteString("**Failing tests**\n\n")
+		for _, c := range tr.Failing {
+			line := fmt.Sprintf("- `%s` — %s (%s)", c.Name, c.Suite, c.Status)
+			if c.Message != "" {
+				line += ": " + c.Message
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	if len(tr.Slowest) > 0 {
+		b.WriteString("**Slowest tests**\n\n")
+		b.WriteString("| Test | Suite | Seconds |\n|---|---|---:|\n")
+		for _, c := range tr.Slowest {
+			b.WriteString(fmt.Sprintf("| %s | %s | %.3f |\n", c.Name, c.Suite, c.Seconds))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// writeTestInventory renderiza a seção "Tests" (inventário estático dos _test.go).
+func writeTestInventory(b *bytes.Buffer, inv *collect.TestInventory) {
+	if inv == nil || (len(inv.Packages) == 0 && len(inv.Untested) == 0) {
+		return
+	}
+	b.WriteString("## Tests\n\n")
+	b.WriteString(fmt.Sprintf("- **Totals:** %d test files in %d packages — tests=%d (table-driven=%d), benchmarks=%d, fuzz=%d, examples=%d\n",
+		inv.TestFiles, len(inv.Packages), inv.Tests, inv.TableDriven, inv.Benchmarks, inv.Fuzz, inv.Examples))
+	if total := len(inv.Packages) + len(inv.Untested); total > 0 {
+		b.WriteString(fmt.Sprintf("- **Packages with tests:** %d/%d\n", len(inv.Packages), total))
+	}
+	b.WriteString("\n")
+
+	if len(inv.Packages) > 0 {
+		b.WriteString("| Package | Files | Test | Table | Bench | Fuzz | Example | Notes |\n")
+		b.WriteString("|---|---:|---:|---:|---:|---:|---:|---|\n")
+		limit := inv.Packages
+		if len(limit) > 60 {
+			limit = limit[:60]
+		}
+		for _, p := range limit {
+			var notes []string
+			if p.ExternalPkg {
+				notes = append(notes, "_test pkg")
+			}
+			if p.HasTestMain {
+				notes = append(notes, "TestMain")
+			}
+			if p.HasTestdata {
+				notes = append(notes, "testdata/")
+			}
+			if len(p.FuzzCorpus) > 0 {
+				notes = append(notes, "fuzz corpus: "+strings.Join(p.FuzzCorpus, ", "))
+			}
+			if p.UsesTestify {
+				notes = append(notes, "testify")
+			}
+			if p.UsesGomock {
+				notes = append(notes, "gomock")
+			}
+			b.WriteString(fmt.Sprintf("| %s | %d | %d | %d | %d | %d | %d | %s |\n",
+				p.Dir, p.TestFiles, p.Tests, p.TableDriven, p.Benchmarks, p.Fuzz, p.Examples, strings.Join(notes, "; ")))
+		}
+		if n := len(inv.Packages) - len(limit); n > 0 {
+			b.WriteString(fmt.Sprintf("| … (%d more) | | | | | | | |\n", n))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(inv.Untested) > 0 {
+		b.WriteString("**Packages without tests**\n\n")
+		x := inv.Untested
+		if len(x) > 40 {
+			x = append(x[:40:40], "…")
+		}
+		for _, d := range x {
+			b.WriteString("- " + d + "\n")
+		}
+		b.WriteString("\n")
+	}
+}
@@ -27,6 +27,11 @@ func main() {
 		includeGlobsStr string
 		excludeGlobsStr string
 		treeDepth       int
+		skipGenerated   bool
+		largeAssetBytes int64
+		historyDays     int
+		rev             string
+		base            string
 	)
 	flag.StringVar(&root, "root", ".", "project root to scan")
 	flag.StringVar(&out, "out", "LLM_SUMMARY.md", "output Markdown artifact path")
@@ -35,6 +40,11 @@ func main() {
 	flag.StringVar(&includeGlobsStr, "include", "", "comma-separated glob patterns to force include (in addition to defaults)")
 	flag.StringVar(&excludeGlobsStr, "exclude", "", "comma-separated glob patterns to exclude (in addition to defaults)")
 	flag.IntVar(&treeDepth, "tree-depth", 3, "max depth for directory tree in the summary")
+	flag.BoolVar(&skipGenerated, "skip-generated", false, "leave generated files (pb.go, mocks, \"Code generated\" headers) out of all collectors and the tree")
+	flag.Int64Var(&largeAssetBytes, "large-asset-bytes", 5*1024*1024, "report files at or above this size that are not tracked by Git LFS (0 disables)")
+	flag.IntVar(&historyDays, "history-days", 90, "git history window in days for the Recent Activity section (0 disables)")
+	flag.StringVar(&rev, "rev", "", "scan this git revision (branch, tag or commit) straight from the local object database instead of the working tree")
+	flag.StringVar(&base, "base", "", "changed-files mode: diff against the merge-base with this ref and focus collectors on the changed files and their package neighbors")
 	flag.Parse()
 
 	absRoot, err := filepath.Abs(root)
@@ -53,6 +63,11 @@ func main() {
 		IncludeGlobsCSV: includeGlobsStr,
 		ExcludeGlobsCSV: excludeGlobsStr,
 		TreeDepth:       treeDepth,
+		SkipGenerated:   skipGenerated,
+		LargeAssetBytes: largeAssetBytes,
+		HistoryDays:     historyDays,
+		Rev:             rev,
+		Base:            base,
 	}
 	sum, err := collect.Scan(ctx, cfg)
 	if err != nil {